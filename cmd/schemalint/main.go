@@ -4,6 +4,9 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 
 	"github.com/spf13/cobra"
 
@@ -30,90 +33,333 @@ Commands:
   generate  - Generate JSON Schema from Go struct types
 
 Profiles:
-  default  - Check for common issues (discriminators, large unions)
-  scale    - Strict mode for static type generation (no composition keywords)`,
+  default    - Check for common issues (discriminators, large unions)
+  scale      - Strict mode for static type generation (no composition keywords)
+  go         - Default checks plus Go code generation (encoding/json) checks
+  typescript - Default checks plus TypeScript checks; accepts number and
+               boolean discriminators
+  rust       - Default checks with unions mapped to serde enum representations
+  protobuf   - Default checks plus constructs with no proto3 equivalent
+  python     - Default checks plus Pydantic v2 (datamodel-codegen) checks`,
 }
 
 var lintCmd = &cobra.Command{
-	Use:   "lint <schema.json>",
+	Use:   "lint <schema.json|dir|-> [...]",
 	Short: "Lint JSON Schema for static type compatibility",
-	Long: `Lint a JSON Schema file and report patterns that cause problems
+	Long: `Lint JSON Schema files and report patterns that cause problems
 when generating code for statically-typed languages.
 
+Files, directories (searched recursively for files matching --include,
+default *.json, *.jsonc, *.yaml and *.yml) and - for standard input may be
+given; --exclude skips matching files and directories, and the config and
+baseline files are never linted. Files are linted concurrently.
+
+Schemas may be written in JSON, JSONC (JSON with comments and trailing
+commas) or YAML. The format is detected from the extension (.yaml, .yml,
+.jsonc) or the content; --input-format overrides it. Issues are reported
+at their line and column in the original source.
+
+Schemas are read as the JSON Schema draft named by $schema (draft-04,
+draft-06, draft-07, 2019-09 or 2020-12, the default); --draft overrides it.
+Earlier drafts' forms such as id, definitions, boolean exclusiveMaximum and
+array-form items are understood.
+
+OpenAPI 3.0 and 3.1 documents (JSON or YAML) are detected, and every schema
+in components, parameters, request bodies and responses is linted.
+
+Schemas are first validated against their draft's metaschema (2020-12 or
+draft-07); violations such as "type": "strin" are reported as
+invalid-schema errors.
+
 Default profile checks:
   - Unions without discriminator fields (error)
   - Inconsistent discriminator field names (error)
   - Missing const values in union variants (error)
+  - Circular references that embed a type in itself (error)
   - Large unions with many variants (warning)
   - Deeply nested unions (warning)
   - additionalProperties on union variants (warning)
+  - Nullable forms not idiomatic for the dialect, e.g. nullable: true
+    outside OpenAPI 3.0 or x-nullable: true outside Swagger 2 (warning)
+  - Keywords the schema's draft does not define or writes differently,
+    and keywords next to $ref before 2019-09 (warning)
 
 Scale profile additionally checks:
-  - Composition keywords anyOf/oneOf/allOf (error)
+  - Composition keywords anyOf/oneOf/allOf, except nullable patterns (error)
   - additionalProperties: true (error)
   - Missing explicit type field (error)
   - Mixed type arrays like ["string", "number"] (error)
 
-Exit codes:
+Go profile additionally checks:
+  - Property or definition names that become the same Go identifier,
+    such as foo_bar and fooBar (error)
+  - Names starting with a digit, and Go keywords (warning, info)
+  - Integers without format int64/int32 or bounds (warning)
+  - Defaults encoding/json cannot decode into the Go type (warning)
+
+TypeScript profile accepts number and boolean const discriminators and
+additionally checks:
+  - Union variants that do not require their discriminator (warning)
+  - additionalProperties next to properties, which becomes an index
+    signature (warning)
+  - Property names that are not JavaScript identifiers (warning)
+
+Rust profile reports the serde representation of each union (internally,
+adjacently or externally tagged, or untagged) as info, and instead of
+unions without discriminators reports:
+  - Untagged unions whose later variants also deserialize as earlier
+    ones, such as integer after number (error)
+
+Protobuf profile additionally checks:
+  - Array or map variants in unions, which a oneof cannot contain (error)
+  - Maps with pattern or enum keys, next to properties, or with array or
+    map values (error)
+  - Arrays of arrays (repeated repeated) (error)
+  - Nullable scalars, which need wrapper types (warning)
+  - Enums whose first value cannot be the zero value (warning)
+
+Python profile additionally checks:
+  - Union variants that do not require their Literal discriminator (error)
+  - Property names that are Python keywords or shadow BaseModel
+    attributes such as json, copy and dict (warning)
+  - Enums mixing value types (warning)
+
+Configuration:
+  Options are read from .schemalint.yaml (or .yml/.json) in the first
+  schema's directory or the nearest parent, or from the file given with
+  --config.
+  Flags override values from the config file.
+
+Rule overrides:
+  Any issue code can be disabled or given a different severity with
+  --rule code=severity (error, warning, info, off) or --disable code, or
+  in the config file's rules section. Overrides affect the exit code.
+
+Suppressions:
+  Add "x-schemalint-ignore" to a schema to suppress issues reported on it:
+  true (all codes), an array of issue codes, or
+  {"codes": [...], "subtree": true} to include nested schemas.
+
+Baselines:
+  --write-baseline file records all current issues and exits 0. Later runs
+  with --baseline file report only issues not in the baseline, so existing
+  problems can be fixed incrementally while new ones still fail the build.
+  Files are recorded relative to the baseline file. Baseline entries that
+  no longer occur are reported on stderr.
+
+Autofix:
+  Some issues have safe mechanical fixes: renaming properties to the
+  configured case (updating required), additionalProperties: false on union
+  variants, type [T, "null"] rewritten as anyOf, and keywords rewritten in
+  the form of the schema's draft. --fix applies them in place, keeping the
+  rest of the file's formatting and comments, and reports the issues that
+  remain; --fix-dry-run prints the changes as a unified diff instead. YAML
+  files are not fixed.
+
+Exit codes (for the worst file):
   0 - No issues found
   1 - Errors found (schema has problems)
   2 - Warnings found but no errors`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MinimumNArgs(1),
 	RunE: runLint,
 }
 
 var (
-	lintOutput       string
-	lintProfile      string
-	lintPropertyCase string
+	lintOutput               string
+	lintProfile              string
+	lintPropertyCase         string
+	lintConfigPath           string
+	lintMaxUnionVariants     int
+	lintMaxUnionNestingDepth int
+	lintDiscriminatorFields  []string
+	lintRules                []string
+	lintDisable              []string
+	lintReportUnused         bool
+	lintBaselinePath         string
+	lintWriteBaselinePath    string
+	lintInclude              []string
+	lintExclude              []string
+	lintJobs                 int
+	lintFix                  bool
+	lintFixDryRun            bool
+	lintInputFormat          string
+	lintDraft                string
 )
 
 func init() {
 	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(versionCmd)
 
-	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", "text", "Output format: text, json, github")
-	lintCmd.Flags().StringVarP(&lintProfile, "profile", "p", "default", "Linting profile: default, scale")
-	lintCmd.Flags().StringVar(&lintPropertyCase, "property-case", "camelCase", "Property case convention: none, camelCase, snake_case, kebab-case, PascalCase")
+	defaults := linter.DefaultConfig()
+	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", "text", "Output format: text, json, github, sarif")
+	lintCmd.Flags().StringVarP(&lintProfile, "profile", "p", string(defaults.Profile), "Linting profile: default, scale, go, typescript, rust, protobuf, python")
+	lintCmd.Flags().StringVar(&lintPropertyCase, "property-case", string(defaults.PropertyCase), "Property case convention: none, camelCase, snake_case, kebab-case, PascalCase")
+	lintCmd.Flags().StringVarP(&lintConfigPath, "config", "c", "", "Config file (default: .schemalint.yaml found in the first schema's directory or a parent)")
+	lintCmd.Flags().IntVar(&lintMaxUnionVariants, "max-union-variants", defaults.MaxUnionVariants, "Threshold for large union warnings")
+	lintCmd.Flags().IntVar(&lintMaxUnionNestingDepth, "max-union-nesting-depth", defaults.MaxUnionNestingDepth, "Threshold for nested union warnings")
+	lintCmd.Flags().StringSliceVar(&lintDiscriminatorFields, "discriminator-fields", defaults.DiscriminatorFields, "Field names to look for as union discriminators")
+	lintCmd.Flags().StringArrayVar(&lintRules, "rule", nil, "Override a rule's severity as code=severity (error, warning, info, off); repeatable")
+	lintCmd.Flags().StringSliceVar(&lintDisable, "disable", nil, "Disable rules by issue code")
+	lintCmd.Flags().BoolVar(&lintReportUnused, "report-unused-suppressions", false, "Report x-schemalint-ignore entries that suppress no issues")
+	lintCmd.Flags().StringVar(&lintBaselinePath, "baseline", "", "Baseline file of known issues; only new issues are reported")
+	lintCmd.Flags().StringVar(&lintWriteBaselinePath, "write-baseline", "", "Write all current issues to a baseline file and exit")
+	lintCmd.Flags().StringSliceVar(&lintInclude, "include", linter.DefaultIncludePatterns, "Glob patterns for files to lint when searching directories")
+	lintCmd.Flags().StringSliceVar(&lintExclude, "exclude", nil, "Glob patterns for files and directories to skip")
+	lintCmd.Flags().IntVarP(&lintJobs, "jobs", "j", runtime.NumCPU(), "Number of files to lint concurrently")
+	lintCmd.Flags().BoolVar(&lintFix, "fix", false, "Apply safe fixes to schema files in place")
+	lintCmd.Flags().BoolVar(&lintFixDryRun, "fix-dry-run", false, "Print the changes --fix would make as a unified diff and exit")
+	lintCmd.Flags().StringVar(&lintDraft, "draft", string(defaults.Draft), "JSON Schema draft: auto, draft-04, draft-06, draft-07, 2019-09, 2020-12")
+	lintCmd.Flags().StringVar(&lintInputFormat, "input-format", string(defaults.InputFormat), "Input format: auto, json, jsonc, yaml")
 }
 
-func runLint(cmd *cobra.Command, args []string) error {
-	schemaPath := args[0]
+// loadLintConfig builds the linter configuration from the config file, if
+// any, with explicitly set flags taking precedence. Without --config, the
+// config file is searched for from the directory of schemaPath.
+func loadLintConfig(cmd *cobra.Command, schemaPath string) (linter.Config, error) {
+	configPath := lintConfigPath
+	if configPath == "" {
+		dir := filepath.Dir(schemaPath)
+		if schemaPath == linter.StdinPath {
+			dir = "."
+		} else if info, err := os.Stat(schemaPath); err == nil && info.IsDir() {
+			dir = schemaPath
+		}
+		found, err := linter.FindConfig(dir)
+		if err != nil {
+			return linter.Config{}, fmt.Errorf("failed to find config: %w", err)
+		}
+		configPath = found
+	}
 
 	config := linter.DefaultConfig()
-	switch lintProfile {
-	case "scale":
-		config.Profile = linter.ProfileScale
-	case "default":
-		config.Profile = linter.ProfileDefault
-	default:
-		return fmt.Errorf("unknown profile: %s (use 'default' or 'scale')", lintProfile)
-	}
-
-	switch lintPropertyCase {
-	case "none":
-		config.PropertyCase = linter.CaseNone
-	case "camelCase":
-		config.PropertyCase = linter.CaseCamel
-	case "snake_case":
-		config.PropertyCase = linter.CaseSnake
-	case "kebab-case":
-		config.PropertyCase = linter.CaseKebab
-	case "PascalCase":
-		config.PropertyCase = linter.CasePascal
-	default:
-		return fmt.Errorf("unknown property case: %s", lintPropertyCase)
+	if configPath != "" {
+		var err error
+		if config, err = linter.LoadConfig(configPath); err != nil {
+			return linter.Config{}, err
+		}
 	}
 
-	l := linter.New(config)
-	result, err := l.LintFile(schemaPath)
+	flags := cmd.Flags()
+	if flags.Changed("profile") {
+		config.Profile = linter.Profile(lintProfile)
+	}
+	if flags.Changed("property-case") {
+		config.PropertyCase = linter.PropertyCase(lintPropertyCase)
+	}
+	if flags.Changed("max-union-variants") {
+		config.MaxUnionVariants = lintMaxUnionVariants
+	}
+	if flags.Changed("max-union-nesting-depth") {
+		config.MaxUnionNestingDepth = lintMaxUnionNestingDepth
+	}
+	if flags.Changed("discriminator-fields") {
+		config.DiscriminatorFields = lintDiscriminatorFields
+	}
+	if flags.Changed("report-unused-suppressions") {
+		config.ReportUnusedSuppressions = lintReportUnused
+	}
+	if flags.Changed("draft") {
+		config.Draft = linter.Draft(lintDraft)
+	}
+	if flags.Changed("input-format") {
+		config.InputFormat = linter.InputFormat(lintInputFormat)
+	}
+	if len(lintRules) > 0 || len(lintDisable) > 0 {
+		rules := make(map[linter.IssueCode]linter.Severity, len(config.Rules))
+		for code, severity := range config.Rules {
+			rules[code] = severity
+		}
+		for _, rule := range lintRules {
+			code, severity, err := linter.ParseRuleOverride(rule)
+			if err != nil {
+				return linter.Config{}, err
+			}
+			rules[code] = severity
+		}
+		for _, code := range lintDisable {
+			rules[linter.IssueCode(code)] = linter.SeverityOff
+		}
+		config.Rules = rules
+	}
+
+	if err := config.Validate(); err != nil {
+		return linter.Config{}, err
+	}
+	return config, nil
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	config, err := loadLintConfig(cmd, args[0])
 	if err != nil {
-		return fmt.Errorf("failed to lint schema: %w", err)
+		return err
+	}
+
+	paths, err := linter.FindSchemaFiles(args, lintInclude, lintExclude)
+	if err != nil {
+		return err
+	}
+	// The config and baseline files are not schemas, even next to them
+	paths = slices.DeleteFunc(paths, func(path string) bool {
+		return sameFile(path, lintConfigPath) || sameFile(path, lintBaselinePath) || sameFile(path, lintWriteBaselinePath)
+	})
+	if len(paths) == 0 {
+		return fmt.Errorf("no schema files found")
+	}
+
+	l := linter.New(config)
+	result := l.LintFiles(paths, lintJobs)
+
+	if lintFix || lintFixDryRun {
+		fixes, err := linter.Fix(result.Results...)
+		if err != nil {
+			return err
+		}
+		if lintFixDryRun {
+			for _, fix := range fixes {
+				fmt.Print(linter.UnifiedDiff(fix.File, fix.Original, fix.Fixed))
+			}
+			return nil
+		}
+		if err := writeFixes(fixes); err != nil {
+			return err
+		}
+		// Report the issues that remain
+		result = l.LintFiles(paths, lintJobs)
+	}
+
+	if lintWriteBaselinePath != "" {
+		baseline := linter.NewBaseline(filepath.Dir(lintWriteBaselinePath), result.Results...)
+		if err := baseline.Write(lintWriteBaselinePath); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d baseline entries to %s\n", len(baseline.Entries), lintWriteBaselinePath)
+		return nil
 	}
+	if lintBaselinePath != "" {
+		baseline, err := linter.LoadBaseline(lintBaselinePath)
+		if err != nil {
+			return err
+		}
+		reportBaseline(baseline.Apply(result.Results...))
+	}
+
+	// A single schema file keeps the single-file JSON document
+	single := len(args) == 1 && len(paths) == 1 && paths[0] == args[0]
 
 	switch lintOutput {
 	case "json":
-		data, err := result.JSON()
+		var data []byte
+		if single {
+			data, err = result.Results[0].JSON()
+		} else {
+			data, err = result.JSON()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to serialize result: %w", err)
+		}
+		fmt.Println(string(data))
+	case "sarif":
+		data, err := result.SARIF()
 		if err != nil {
 			return fmt.Errorf("failed to serialize result: %w", err)
 		}
@@ -134,6 +380,59 @@ func runLint(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// writeFixes writes fixed files in place and summarizes the fixes on stderr.
+func writeFixes(fixes []linter.FileFix) error {
+	for _, fix := range fixes {
+		if fix.Applied > 0 {
+			info, err := os.Stat(fix.File)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(fix.File, fix.Fixed, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to write fixes: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Applied %d fix(es) to %s\n", fix.Applied, fix.File)
+		}
+		switch {
+		case fix.Skipped == 0:
+		case fix.Format == linter.FormatYAML:
+			fmt.Fprintf(os.Stderr, "Skipped %d fix(es) in %s; fixes cannot be applied to YAML files\n", fix.Skipped, fix.File)
+		default:
+			fmt.Fprintf(os.Stderr, "Skipped %d conflicting fix(es) in %s; run --fix again to apply them\n", fix.Skipped, fix.File)
+		}
+	}
+	return nil
+}
+
+// sameFile reports whether path and other name the same file. other may be
+// empty or not exist yet.
+func sameFile(path, other string) bool {
+	if other == "" || path == linter.StdinPath {
+		return false
+	}
+	a, errA := filepath.Abs(path)
+	b, errB := filepath.Abs(other)
+	return errA == nil && errB == nil && a == b
+}
+
+// reportBaseline prints the effect of applying a baseline to stderr, keeping
+// stdout limited to the selected output format.
+func reportBaseline(report linter.BaselineReport) {
+	if report.Suppressed > 0 {
+		fmt.Fprintf(os.Stderr, "%d known issue(s) suppressed by baseline\n", report.Suppressed)
+	}
+	for _, entry := range report.Fixed {
+		location := entry.Path
+		if entry.File != "" {
+			location = entry.File + ": " + location
+		}
+		fmt.Fprintf(os.Stderr, "Fixed baseline issue: [%s] %s (x%d)\n", entry.Code, location, entry.Count)
+	}
+	if len(report.Fixed) > 0 {
+		fmt.Fprintln(os.Stderr, "Run with --write-baseline to update the baseline.")
+	}
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
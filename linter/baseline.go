@@ -0,0 +1,188 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// baselineVersion is the current baseline file format version.
+const baselineVersion = 1
+
+// Baseline records known issues so that only new issues affect a lint run.
+// Issues are matched by fingerprint (code, file and normalized path), with a
+// count per fingerprint so that new occurrences of a known issue still fail.
+// Files are recorded relative to the baseline file's directory, so a
+// baseline matches however the files are named on the command line.
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+
+	dir string // directory files are recorded relative to
+}
+
+// BaselineEntry is a group of known issues sharing a fingerprint.
+type BaselineEntry struct {
+	Fingerprint string    `json:"fingerprint"`
+	Code        IssueCode `json:"code"`
+	File        string    `json:"file,omitempty"`
+	Path        string    `json:"path"`
+	Count       int       `json:"count"`
+}
+
+// NewBaseline creates a baseline from the issues in results, for a baseline
+// file in dir.
+func NewBaseline(dir string, results ...*Result) *Baseline {
+	b := &Baseline{Version: baselineVersion, Entries: []BaselineEntry{}, dir: dir}
+	entries := make(map[string]*BaselineEntry)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			file := b.relativeFile(issue.File)
+			fingerprint := issueFingerprint(issue.Code, file, issue.Path)
+			if entry, ok := entries[fingerprint]; ok {
+				entry.Count++
+				continue
+			}
+			entries[fingerprint] = &BaselineEntry{
+				Fingerprint: fingerprint,
+				Code:        issue.Code,
+				File:        file,
+				Path:        NormalizePath(issue.Path),
+				Count:       1,
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		b.Entries = append(b.Entries, *entry)
+	}
+	sort.Slice(b.Entries, func(i, j int) bool {
+		a, c := b.Entries[i], b.Entries[j]
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.Path != c.Path {
+			return a.Path < c.Path
+		}
+		return a.Code < c.Code
+	})
+	return b
+}
+
+// LoadBaseline reads a baseline file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s", b.Version, path)
+	}
+	b.dir = filepath.Dir(path)
+	return &b, nil
+}
+
+// Write saves the baseline as JSON.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// BaselineReport summarizes the effect of applying a baseline.
+type BaselineReport struct {
+	// Suppressed is the number of known issues removed from the results.
+	Suppressed int
+	// Fixed lists baseline entries that no longer occur (Count holds how many
+	// occurrences went away), so the baseline can be shrunk.
+	Fixed []BaselineEntry
+}
+
+// Apply removes known issues from results and reports baseline entries that
+// were fixed. Entries are only reported as fixed for files covered by results.
+func (b *Baseline) Apply(results ...*Result) BaselineReport {
+	remaining := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+
+	var report BaselineReport
+	linted := make(map[string]bool)
+	for _, result := range results {
+		linted[b.relativeFile(result.SchemaPath)] = true
+		kept := result.Issues[:0]
+		for _, issue := range result.Issues {
+			file := b.relativeFile(issue.File)
+			linted[file] = true
+			fingerprint := issueFingerprint(issue.Code, file, issue.Path)
+			if remaining[fingerprint] > 0 {
+				remaining[fingerprint]--
+				report.Suppressed++
+				continue
+			}
+			kept = append(kept, issue)
+		}
+		result.Issues = kept
+	}
+
+	for _, entry := range b.Entries {
+		if count := remaining[entry.Fingerprint]; count > 0 && linted[entry.File] {
+			entry.Count = min(count, entry.Count)
+			remaining[entry.Fingerprint] -= entry.Count
+			report.Fixed = append(report.Fixed, entry)
+		}
+	}
+	return report
+}
+
+// relativeFile returns file relative to the baseline's directory, as a
+// slash-separated path. Files that cannot be made relative are cleaned
+// instead (see cleanFile).
+func (b *Baseline) relativeFile(file string) string {
+	file = cleanFile(file)
+	if file == "" || file == stdinFile || strings.Contains(file, "://") {
+		return file
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	dir, err := filepath.Abs(b.dir)
+	if err != nil {
+		return file
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
+
+// arrayKeywords are the keywords whose subschemas are addressed by index.
+var arrayKeywords = []string{"anyOf", "oneOf", "allOf", "prefixItems", "items"}
+
+// NormalizePath makes an issue path independent of array positions by
+// replacing subschema indexes (e.g. union variants) with "*", so that
+// reordering variants does not change an issue's fingerprint.
+func NormalizePath(path string) string {
+	tokens := strings.Split(path, "/")
+	for i := 1; i < len(tokens); i++ {
+		if slices.Contains(arrayKeywords, tokens[i-1]) && tokens[i] != "" && strings.Trim(tokens[i], "0123456789") == "" {
+			tokens[i] = "*"
+		}
+	}
+	return strings.Join(tokens, "/")
+}
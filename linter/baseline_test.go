@@ -0,0 +1,154 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const baselineSchema = `{
+	"$defs": {
+		"Union": {
+			"anyOf": [
+				{"type": "object", "properties": {"type": {"const": "a"}}, "additionalProperties": true},
+				{"type": "object", "properties": {"type": {"const": "b"}}}
+			]
+		}
+	}
+}`
+
+func lintBaselineSchema(t *testing.T, schema string) *Result {
+	t.Helper()
+	result, err := NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	return result
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	result := lintBaselineSchema(t, baselineSchema)
+	if len(result.Issues) == 0 {
+		t.Fatal("Expected issues to baseline")
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := NewBaseline(filepath.Dir(path), result).Write(path); err != nil {
+		t.Fatalf("Failed to write baseline: %v", err)
+	}
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+
+	report := baseline.Apply(result)
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected all issues to be suppressed, got: %v", result.Issues)
+	}
+	if report.Suppressed == 0 || len(report.Fixed) != 0 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestBaselineSurvivesReordering(t *testing.T) {
+	baseline := NewBaseline("", lintBaselineSchema(t, baselineSchema))
+
+	reordered := `{
+		"$defs": {
+			"Union": {
+				"anyOf": [
+					{"type": "object", "properties": {"type": {"const": "b"}}},
+					{"type": "object", "properties": {"type": {"const": "a"}}, "additionalProperties": true}
+				]
+			}
+		}
+	}`
+	result := lintBaselineSchema(t, reordered)
+	baseline.Apply(result)
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected reordered variants to match the baseline, got: %v", result.Issues)
+	}
+}
+
+func TestBaselineNewAndFixedIssues(t *testing.T) {
+	baseline := NewBaseline("", lintBaselineSchema(t, baselineSchema))
+
+	changed := `{
+		"$defs": {
+			"Union": {
+				"anyOf": [
+					{"type": "object", "properties": {"type": {"const": "a"}}},
+					{"type": "object", "properties": {"type": {"const": "b"}}}
+				]
+			},
+			"Other": {
+				"oneOf": [
+					{"type": "object", "properties": {"name": {"type": "string"}}},
+					{"type": "object", "properties": {"title": {"type": "string"}}}
+				]
+			}
+		}
+	}`
+	result := lintBaselineSchema(t, changed)
+	report := baseline.Apply(result)
+
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeUnionNoDiscriminator {
+		t.Errorf("Expected only the new union issue, got: %v", result.Issues)
+	}
+	if len(report.Fixed) != 1 || report.Fixed[0].Code != CodeAdditionalProps {
+		t.Errorf("Expected the additional-properties entry to be fixed, got: %+v", report.Fixed)
+	}
+}
+
+func TestBaselineMatchesPathSpellings(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "s.json"), []byte(baselineSchema), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	t.Chdir(dir)
+	written, err := NewWithDefaults().LintFile("s.json")
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if err := NewBaseline(filepath.Dir(baselinePath), written).Write(baselinePath); err != nil {
+		t.Fatalf("Failed to write baseline: %v", err)
+	}
+
+	for _, spelling := range []struct{ cwd, file string }{
+		{dir, "./s.json"},
+		{dir, filepath.Join(dir, "s.json")},
+		{filepath.Join(dir, "sub"), "../s.json"},
+	} {
+		t.Chdir(spelling.cwd)
+		baseline, err := LoadBaseline(baselinePath)
+		if err != nil {
+			t.Fatalf("Failed to load baseline: %v", err)
+		}
+		result, err := NewWithDefaults().LintFile(spelling.file)
+		if err != nil {
+			t.Fatalf("Failed to lint: %v", err)
+		}
+		report := baseline.Apply(result)
+		if len(result.Issues) != 0 || len(report.Fixed) != 0 {
+			t.Errorf("Expected %s from %s to match the baseline, got %v (fixed %+v)", spelling.file, spelling.cwd, result.Issues, report.Fixed)
+		}
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"$/$defs/Union/anyOf/1":             "$/$defs/Union/anyOf/*",
+		"$/oneOf/0/properties/x/allOf/12":   "$/oneOf/*/properties/x/allOf/*",
+		"$/properties/200/properties/items": "$/properties/200/properties/items",
+	}
+	for path, want := range tests {
+		if got := NormalizePath(path); got != want {
+			t.Errorf("NormalizePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
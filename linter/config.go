@@ -0,0 +1,146 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames are the configuration file names FindConfig looks for, in order.
+var ConfigFileNames = []string{".schemalint.yaml", ".schemalint.yml", ".schemalint.json"}
+
+// Profiles returns all supported linting profiles: the built-in profiles
+// followed by any further profiles named by registered rules.
+func Profiles() []Profile {
+	profiles := []Profile{ProfileDefault, ProfileScale, ProfileGo, ProfileTypeScript, ProfileRust, ProfileProtobuf, ProfilePython}
+	for _, rule := range Rules() {
+		for _, profile := range rule.Profiles() {
+			if !slices.Contains(profiles, profile) {
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+	return profiles
+}
+
+// PropertyCases returns all supported property case conventions.
+func PropertyCases() []PropertyCase {
+	return []PropertyCase{CaseNone, CaseCamel, CaseSnake, CaseKebab, CasePascal}
+}
+
+// Validate checks that the configuration values are supported.
+func (c Config) Validate() error {
+	if !slices.Contains(Profiles(), c.Profile) {
+		return fmt.Errorf("unknown profile: %s (use %s)", c.Profile, joinProfiles(Profiles()))
+	}
+	if !slices.Contains(PropertyCases(), c.PropertyCase) {
+		return fmt.Errorf("unknown property case: %s", c.PropertyCase)
+	}
+	if c.Draft != "" && !slices.Contains(Drafts(), c.Draft) {
+		return fmt.Errorf("unknown draft: %s", c.Draft)
+	}
+	if c.InputFormat != "" && !slices.Contains(InputFormats(), c.InputFormat) {
+		return fmt.Errorf("unknown input format: %s", c.InputFormat)
+	}
+	if c.MaxUnionVariants < 1 {
+		return fmt.Errorf("max_union_variants must be at least 1, got %d", c.MaxUnionVariants)
+	}
+	if c.MaxUnionNestingDepth < 0 {
+		return fmt.Errorf("max_union_nesting_depth must not be negative, got %d", c.MaxUnionNestingDepth)
+	}
+	for code, severity := range c.Rules {
+		if !slices.Contains(IssueCodes(), code) {
+			return fmt.Errorf("unknown rule: %s", code)
+		}
+		if !slices.Contains(Severities(), severity) {
+			return fmt.Errorf("unknown severity for rule %s: %s (use error, warning, info or off)", code, severity)
+		}
+	}
+	return nil
+}
+
+// ParseRuleOverride parses a "code=severity" rule override.
+func ParseRuleOverride(s string) (IssueCode, Severity, error) {
+	code, severity, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid rule override %q (use code=severity)", s)
+	}
+	return IssueCode(strings.TrimSpace(code)), Severity(strings.TrimSpace(severity)), nil
+}
+
+// joinProfiles formats profile names for error messages ('default', 'scale').
+func joinProfiles(profiles []Profile) string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = "'" + string(p) + "'"
+	}
+	return strings.Join(names, ", ")
+}
+
+// LoadConfig reads a YAML or JSON configuration file. Keys that are not set
+// keep their DefaultConfig values; unknown keys are an error.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config: %w", err)
+	}
+	config, err := ParseConfig(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseConfig parses configuration data as JSON or YAML on top of DefaultConfig.
+func ParseConfig(data []byte, isJSON bool) (Config, error) {
+	config := DefaultConfig()
+
+	if isJSON {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&config); err != nil {
+			return Config{}, err
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, err
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// FindConfig looks for a configuration file in dir and its parent directories.
+// It returns an empty path if none is found.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,109 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigYAML(t *testing.T) {
+	data := `
+profile: scale
+property_case: snake_case
+max_union_variants: 5
+discriminator_fields: [kind]
+`
+	config, err := ParseConfig([]byte(data), false)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	want := DefaultConfig()
+	want.Profile = ProfileScale
+	want.PropertyCase = CaseSnake
+	want.MaxUnionVariants = 5
+	want.DiscriminatorFields = []string{"kind"}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Expected %+v, got %+v", want, config)
+	}
+}
+
+func TestParseConfigStrict(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		isJSON bool
+	}{
+		{"yaml unknown key", "max_union_variant: 5\n", false},
+		{"json unknown key", `{"profle": "scale"}`, true},
+		{"unknown profile", "profile: strict\n", false},
+		{"unknown property case", `{"property_case": "camel"}`, true},
+	}
+	for _, tt := range tests {
+		if _, err := ParseConfig([]byte(tt.data), tt.isJSON); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestFindConfigWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "schemas", "v1")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(root, ".schemalint.yaml")
+	if err := os.WriteFile(configPath, []byte("max_union_nesting_depth: 4\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := FindConfig(nested)
+	if err != nil {
+		t.Fatalf("Failed to find config: %v", err)
+	}
+	if found != configPath {
+		t.Errorf("Expected %s, got %s", configPath, found)
+	}
+
+	config, err := LoadConfig(found)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.MaxUnionNestingDepth != 4 || config.MaxUnionVariants != 10 {
+		t.Errorf("Expected file value over defaults, got %+v", config)
+	}
+
+	if _, err := LoadConfig(filepath.Join(root, "missing.yaml")); err == nil || !strings.Contains(err.Error(), "failed to read config") {
+		t.Errorf("Expected read error, got %v", err)
+	}
+}
+
+func TestParseConfigRules(t *testing.T) {
+	data := `
+rules:
+  large-union: error
+  nested-union: off
+`
+	config, err := ParseConfig([]byte(data), false)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if config.RuleSeverity(CodeLargeUnion, SeverityWarning) != SeverityError {
+		t.Error("Expected large-union to be promoted to error")
+	}
+	if config.RuleSeverity(CodeNestedUnion, SeverityWarning) != SeverityOff {
+		t.Error("Expected nested-union to be disabled")
+	}
+	if config.RuleSeverity(CodeMissingConst, SeverityError) != SeverityError {
+		t.Error("Expected rules without overrides to keep their severity")
+	}
+
+	if _, err := ParseConfig([]byte("rules:\n  large-unions: error\n"), false); err == nil {
+		t.Error("Expected error for unknown rule")
+	}
+	if _, err := ParseConfig([]byte("rules:\n  large-union: fatal\n"), false); err == nil {
+		t.Error("Expected error for unknown severity")
+	}
+}
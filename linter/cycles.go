@@ -0,0 +1,266 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// refEdge is a $ref from one definition to another. An edge is strong when the
+// target is embedded by value in generated Go code (a required, non-nullable
+// property, an allOf member or a plain alias) and weak when it goes through an
+// array, map, union or optional field and therefore a pointer, slice or map.
+type refEdge struct {
+	to     string
+	strong bool
+}
+
+// refGraph is the reference graph between the root schemas of a document and
+// their definitions, keyed by JSON Pointer ("" is the root of a JSON Schema
+// document; OpenAPI documents have a root per component schema).
+type refGraph struct {
+	uri   string
+	nodes []string
+	edges map[string][]refEdge
+}
+
+// lintCycles reports circular $ref chains between definitions. Cycles made only
+// of strong edges describe a type that contains itself by value, which Go
+// cannot represent; other cycles are legitimate recursive types.
+func lintCycles(ctx *RuleContext, doc *document) {
+	graph := buildRefGraph(doc, ctx.resolver)
+
+	// Definitions already in a reported cycle; a recursive type containing
+	// them would repeat that cycle
+	embedded := make(map[string]bool)
+	for _, component := range graph.components(true) {
+		cycle := graph.shortestCycle(component, true)
+		if cycle == nil {
+			continue
+		}
+		for _, pointer := range component {
+			embedded[pointer] = true
+		}
+		ctx.Report(Issue{
+			Severity:   SeverityError,
+			Path:       "$" + cycle[0],
+			Message:    fmt.Sprintf("Circular reference %s embeds itself by value", formatCycle(cycle)),
+			Suggestion: "Make one of the references optional, or move it into an array or map",
+			Cycle:      cycleRefs(cycle),
+		})
+	}
+
+	for _, component := range graph.components(false) {
+		if slices.ContainsFunc(component, func(pointer string) bool { return embedded[pointer] }) {
+			continue
+		}
+		cycle := graph.shortestCycle(component, false)
+		if cycle == nil {
+			continue
+		}
+		ctx.Report(Issue{
+			Severity:   SeverityInfo,
+			Path:       "$" + cycle[0],
+			Message:    fmt.Sprintf("Recursive type %s", formatCycle(cycle)),
+			Suggestion: "Generated Go types must break this cycle with a pointer, slice or map",
+			Cycle:      cycleRefs(cycle),
+		})
+	}
+}
+
+// buildRefGraph collects $ref edges between the root schemas of doc and their top-level definitions.
+func buildRefGraph(doc *document, resolver *Resolver) *refGraph {
+	graph := &refGraph{
+		uri:   doc.uri,
+		edges: make(map[string][]refEdge),
+	}
+	defs := make(map[string]*Schema)
+	add := func(pointer string, schema *Schema) {
+		graph.nodes = append(graph.nodes, pointer)
+		defs[pointer] = schema
+	}
+
+	for _, root := range doc.schemas {
+		add(root.pointer, root.schema)
+		for _, name := range sortedKeys(root.schema.Defs) {
+			add(root.pointer+"/$defs/"+EscapePointerToken(name), root.schema.Defs[name])
+		}
+		for _, name := range sortedKeys(root.schema.Definitions) {
+			add(root.pointer+"/definitions/"+EscapePointerToken(name), root.schema.Definitions[name])
+		}
+	}
+
+	for _, from := range graph.nodes {
+		graph.collectEdges(from, defs[from], true, resolver)
+	}
+	return graph
+}
+
+// collectEdges walks schema (owned by definition from) and records every $ref it contains.
+func (g *refGraph) collectEdges(from string, schema *Schema, strong bool, resolver *Resolver) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		// Only references within the linted document take part in the graph
+		if target, err := resolver.lookup(schema, schema.Ref); err == nil && target.URI == g.uri {
+			g.edges[from] = append(g.edges[from], refEdge{to: g.owner(target.Pointer), strong: strong})
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		prop := schema.Properties[name]
+		embedded := strong && slices.Contains(schema.Required, name) && prop != nil && !prop.IsNullable()
+		g.collectEdges(from, prop, embedded, resolver)
+	}
+	for _, member := range schema.AllOf {
+		g.collectEdges(from, member, strong, resolver)
+	}
+	for _, sub := range schema.subschemas() {
+		switch sub.keyword {
+		case "properties", "allOf":
+			// Handled above
+		case "$defs", "definitions", "if", "not", "propertyNames":
+			// Nested definitions are separate types; conditions and name
+			// constraints do not describe values that are stored
+		default:
+			// Unions, containers and conditional branches hold their targets indirectly
+			g.collectEdges(from, sub.schema, false, resolver)
+		}
+	}
+}
+
+// owner returns the graph node that contains pointer.
+func (g *refGraph) owner(pointer string) string {
+	best := ""
+	for _, node := range g.nodes {
+		if (pointer == node || strings.HasPrefix(pointer, node+"/")) && len(node) > len(best) {
+			best = node
+		}
+	}
+	return best
+}
+
+// successors returns the distinct targets reachable from node in one hop.
+func (g *refGraph) successors(node string, strongOnly bool) []string {
+	var next []string
+	for _, edge := range g.edges[node] {
+		if strongOnly && !edge.strong {
+			continue
+		}
+		if !slices.Contains(next, edge.to) {
+			next = append(next, edge.to)
+		}
+	}
+	return next
+}
+
+// components returns the strongly connected components of the graph that
+// contain at least one cycle, using Tarjan's algorithm.
+func (g *refGraph) components(strongOnly bool) [][]string {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(node string)
+	connect = func(node string) {
+		index[node] = len(index)
+		lowlink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range g.successors(node, strongOnly) {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				lowlink[node] = min(lowlink[node], lowlink[next])
+			} else if onStack[next] {
+				lowlink[node] = min(lowlink[node], index[next])
+			}
+		}
+
+		if lowlink[node] != index[node] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || slices.Contains(g.successors(node, strongOnly), node) {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, node := range g.nodes {
+		if _, visited := index[node]; !visited {
+			connect(node)
+		}
+	}
+	return components
+}
+
+// shortestCycle returns the shortest cycle through the first node of
+// component, as a list of pointers that starts and ends with that node.
+func (g *refGraph) shortestCycle(component []string, strongOnly bool) []string {
+	if len(component) == 0 {
+		return nil
+	}
+	start := component[0]
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range g.successors(node, strongOnly) {
+			if !slices.Contains(component, next) {
+				continue
+			}
+			if next == start {
+				cycle := []string{start}
+				for n := node; n != start; n = parent[n] {
+					cycle = append(cycle, n)
+				}
+				cycle = append(cycle, start)
+				slices.Reverse(cycle)
+				return cycle
+			}
+			if _, seen := parent[next]; !seen {
+				parent[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// cycleRefs converts cycle pointers into $ref strings.
+func cycleRefs(cycle []string) []string {
+	refs := make([]string, len(cycle))
+	for i, pointer := range cycle {
+		refs[i] = "#" + pointer
+	}
+	return refs
+}
+
+func formatCycle(cycle []string) string {
+	return strings.Join(cycleRefs(cycle), " -> ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,145 @@
+package linter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintCircularReferenceByValue(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"A": {
+				"type": "object",
+				"properties": {"b": {"$ref": "#/$defs/B"}},
+				"required": ["b"]
+			},
+			"B": {
+				"allOf": [{"$ref": "#/$defs/A"}]
+			}
+		}
+	}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	var found *Issue
+	for i, issue := range result.Issues {
+		if issue.Code == CodeCircularReference {
+			found = &result.Issues[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("Expected circular-reference issue, got: %v", result.Issues)
+	}
+	if found.Severity != SeverityError {
+		t.Errorf("Expected error severity, got %s", found.Severity)
+	}
+	want := []string{"#/$defs/A", "#/$defs/B", "#/$defs/A"}
+	if !reflect.DeepEqual(found.Cycle, want) {
+		t.Errorf("Expected cycle %v, got %v", want, found.Cycle)
+	}
+}
+
+func TestLintRecursiveTypeIsInfo(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Node": {
+				"type": "object",
+				"properties": {
+					"children": {"type": "array", "items": {"$ref": "#/$defs/Node"}},
+					"parent": {"$ref": "#/$defs/Node"}
+				}
+			}
+		}
+	}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	count := 0
+	for _, issue := range result.Issues {
+		if issue.Code != CodeCircularReference {
+			continue
+		}
+		count++
+		if issue.Severity != SeverityInfo {
+			t.Errorf("Expected info severity for recursive type, got %s", issue.Severity)
+		}
+		if issue.Path != "$/$defs/Node" {
+			t.Errorf("Expected path $/$defs/Node, got %s", issue.Path)
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 circular-reference issue, got %d: %v", count, result.Issues)
+	}
+	if result.HasErrors() {
+		t.Errorf("Recursive type through array should not be an error: %v", result.Issues)
+	}
+}
+
+func TestLintRecursiveTypeContainingCycleByValue(t *testing.T) {
+	// A and B embed each other by value; the optional B -> C -> A edge makes
+	// a larger recursive type that must not report the same chain again
+	schema := `{
+		"$defs": {
+			"A": {
+				"type": "object",
+				"properties": {"b": {"$ref": "#/$defs/B"}},
+				"required": ["b"]
+			},
+			"B": {
+				"type": "object",
+				"properties": {
+					"a": {"$ref": "#/$defs/A"},
+					"c": {"$ref": "#/$defs/C"}
+				},
+				"required": ["a"]
+			},
+			"C": {
+				"type": "object",
+				"properties": {"a": {"$ref": "#/$defs/A"}}
+			}
+		}
+	}`
+
+	result, err := NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	var cycles []Issue
+	for _, issue := range result.Issues {
+		if issue.Code == CodeCircularReference {
+			cycles = append(cycles, issue)
+		}
+	}
+	if len(cycles) != 1 || cycles[0].Severity != SeverityError || cycles[0].Path != "$/$defs/A" {
+		t.Errorf("Expected one circular-reference error at $/$defs/A, got %v", cycles)
+	}
+}
+
+func TestGitHubAnnotationsRecursiveTypeAsNotice(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Tree": {
+				"type": "object",
+				"properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/Tree"}}}
+			}
+		}
+	}`
+	result, err := NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	got := result.GitHubAnnotations()
+	if !strings.HasPrefix(got, "::notice ") || strings.Contains(got, "::warning") {
+		t.Errorf("Expected a notice annotation for a recursive type, got %q", got)
+	}
+}
@@ -0,0 +1,157 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// UnifiedDiff returns a unified diff between the old and new contents of
+// file, or "" if they are equal.
+func UnifiedDiff(file string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(old)), splitLines(string(new)))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", file, file)
+	for start := 0; start < len(ops); {
+		// Find the next change and the end of its hunk
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		end := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+		from := max(first-diffContext, start)
+		to := min(end+diffContext, len(ops))
+		writeHunk(&sb, ops, from, to)
+		start = to
+	}
+	return sb.String()
+}
+
+// diffOp is one line of an edit script: kept (' '), removed ('-') or added ('+').
+type diffOp struct {
+	kind     byte
+	line     string
+	old, new int // 0-based line numbers before the op
+}
+
+// writeHunk writes ops[from:to] as a unified diff hunk.
+func writeHunk(sb *strings.Builder, ops []diffOp, from, to int) {
+	oldCount, newCount := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+	}
+	oldStart, newStart := ops[from].old+1, ops[from].new+1
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, op := range ops[from:to] {
+		sb.WriteByte(op.kind)
+		sb.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitLines splits s into lines, keeping line terminators.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest edit script from a to b using Myers' algorithm.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset, d)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the Myers trace back from (len(a), len(b)) to build the edit script.
+func backtrack(a, b []string, trace [][]int, offset, d int) []diffOp {
+	x, y := len(a), len(b)
+	var ops []diffOp
+	for ; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: ' ', line: a[x], old: x, new: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, diffOp{kind: '+', line: b[y], old: x, new: y})
+		} else {
+			x--
+			ops = append(ops, diffOp{kind: '-', line: a[x], old: x, new: y})
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
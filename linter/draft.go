@@ -0,0 +1,142 @@
+package linter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Draft is a version of the JSON Schema specification. It decides which
+// keywords a schema may use and what form they take.
+type Draft string
+
+const (
+	// DraftAuto detects the draft from $schema, defaulting to Draft2020.
+	DraftAuto Draft = "auto"
+	// Draft4 is draft-04, which uses id, boolean exclusive bounds and
+	// array-form items for tuples. OpenAPI 3.0 schemas follow it.
+	Draft4 Draft = "draft-04"
+	// Draft6 is draft-06, which introduced $id, const and numeric exclusive bounds.
+	Draft6 Draft = "draft-06"
+	// Draft7 is draft-07, which introduced if/then/else.
+	Draft7 Draft = "draft-07"
+	// Draft2019 is draft 2019-09, which introduced $defs, $anchor and the
+	// unevaluated and dependent keywords.
+	Draft2019 Draft = "2019-09"
+	// Draft2020 is draft 2020-12, which replaced array-form items with
+	// prefixItems. OpenAPI 3.1 schemas follow it.
+	Draft2020 Draft = "2020-12"
+)
+
+// Drafts returns all supported drafts, oldest first, after DraftAuto.
+func Drafts() []Draft {
+	return []Draft{DraftAuto, Draft4, Draft6, Draft7, Draft2019, Draft2020}
+}
+
+// DetectDraft returns the draft identified by a $schema URI such as
+// "http://json-schema.org/draft-07/schema#", or "" if it names none.
+func DetectDraft(schemaURI string) Draft {
+	uri := strings.TrimSuffix(stripFragment(schemaURI), "/")
+	uri = strings.TrimPrefix(strings.TrimPrefix(uri, "http://"), "https://")
+	switch uri {
+	case "json-schema.org/draft-04/schema":
+		return Draft4
+	case "json-schema.org/draft-06/schema":
+		return Draft6
+	case "json-schema.org/draft-07/schema":
+		return Draft7
+	case "json-schema.org/draft/2019-09/schema":
+		return Draft2019
+	case "json-schema.org/draft/2020-12/schema":
+		return Draft2020
+	}
+	return ""
+}
+
+// before reports whether d is an older draft than other.
+func (d Draft) before(other Draft) bool {
+	return draftOrder(d) < draftOrder(other)
+}
+
+// draftOrder returns the position of d among the drafts, oldest first.
+func draftOrder(d Draft) int {
+	for i, draft := range Drafts() {
+		if draft == d {
+			return i
+		}
+	}
+	return len(Drafts())
+}
+
+// documentDraft returns the draft of a document: override unless it is
+// DraftAuto, draft-04 for OpenAPI 3.0, the jsonSchemaDialect of OpenAPI 3.1
+// (2020-12 by default), or the draft named by the root's $schema.
+func documentDraft(doc *document, override Draft) Draft {
+	if override != "" && override != DraftAuto {
+		return override
+	}
+	// Read from the data, since the schema may not parse
+	var root struct {
+		Schema  string `json:"$schema"`
+		Dialect string `json:"jsonSchemaDialect"`
+	}
+	_ = json.Unmarshal(doc.data, &root)
+	schemaURI := root.Schema
+	switch doc.dialect() {
+	case dialectOpenAPI30, dialectSwagger2:
+		return Draft4
+	case dialectOpenAPI31:
+		schemaURI = root.Dialect
+	}
+	if draft := DetectDraft(schemaURI); draft != "" {
+		return draft
+	}
+	return Draft2020
+}
+
+// id returns the identifier of s under draft: id in draft-04, $id later.
+func (s *Schema) id(draft Draft) string {
+	if draft == Draft4 {
+		return s.LegacyID
+	}
+	return s.ID
+}
+
+// draftKeyword is a keyword that only some drafts define.
+type draftKeyword struct {
+	keyword string
+	since   Draft // first draft defining the keyword
+	until   Draft // last draft defining it, if it was removed
+	instead string
+}
+
+// draftKeywords lists the keywords added or removed since draft-04 that
+// schemas mix up most often.
+var draftKeywords = []draftKeyword{
+	{keyword: "id", since: Draft4, until: Draft4, instead: "$id"},
+	{keyword: "$id", since: Draft6, instead: "id"},
+	{keyword: "const", since: Draft6},
+	{keyword: "contains", since: Draft6},
+	{keyword: "propertyNames", since: Draft6},
+	{keyword: "if", since: Draft7},
+	{keyword: "then", since: Draft7},
+	{keyword: "else", since: Draft7},
+	{keyword: "$anchor", since: Draft2019},
+	{keyword: "dependentRequired", since: Draft2019, instead: "dependencies"},
+	{keyword: "dependentSchemas", since: Draft2019, instead: "dependencies"},
+	{keyword: "unevaluatedProperties", since: Draft2019},
+	{keyword: "unevaluatedItems", since: Draft2019},
+	{keyword: "minContains", since: Draft2019},
+	{keyword: "maxContains", since: Draft2019},
+	{keyword: "dependencies", since: Draft4, until: Draft7, instead: "dependentRequired or dependentSchemas"},
+	{keyword: "$dynamicRef", since: Draft2020},
+	{keyword: "$dynamicAnchor", since: Draft2020},
+	{keyword: "prefixItems", since: Draft2020, instead: "array-form items"},
+	{keyword: "additionalItems", since: Draft4, until: Draft2019, instead: "items with prefixItems"},
+}
+
+// refAnnotations are the keywords that may sit next to $ref in drafts that
+// ignore its siblings without changing what the schema means.
+var refAnnotations = []string{
+	"$ref", "$schema", "$id", "id", "$comment", "title", "description", "default",
+	"examples", "readOnly", "writeOnly", "deprecated", "definitions", "$defs",
+}
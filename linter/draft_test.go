@@ -0,0 +1,154 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectDraft(t *testing.T) {
+	tests := map[string]Draft{
+		"http://json-schema.org/draft-04/schema#":        Draft4,
+		"http://json-schema.org/draft-06/schema#":        Draft6,
+		"https://json-schema.org/draft-07/schema":        Draft7,
+		"https://json-schema.org/draft/2019-09/schema":   Draft2019,
+		"https://json-schema.org/draft/2020-12/schema":   Draft2020,
+		"https://json-schema.org/draft/2020-12/schema#":  Draft2020,
+		"https://spec.openapis.org/oas/3.1/dialect/base": "",
+		"": "",
+	}
+	for uri, want := range tests {
+		if got := DetectDraft(uri); got != want {
+			t.Errorf("DetectDraft(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
+
+func TestLintDraft07(t *testing.T) {
+	schema := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "array",
+		"items": [
+			{"oneOf": [{"type": "string"}, {"type": "integer"}]},
+			{"$ref": "#/definitions/Size"}
+		],
+		"additionalItems": false,
+		"dependencies": {
+			"a": {"oneOf": [{"required": ["b"]}, {"required": ["c"]}]},
+			"d": ["e"]
+		},
+		"definitions": {
+			"Size": {"type": "number", "maximum": 10, "exclusiveMaximum": true},
+			"Named": {"$ref": "#/definitions/Size", "type": "integer", "description": "ok"}
+		}
+	}`
+	result, err := NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if result.Draft != Draft7 {
+		t.Errorf("Expected draft-07, got %q", result.Draft)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/items/0/oneOf":                     CodeUnionNoDiscriminator,
+		"$/dependencies/a/oneOf":              CodeUnionNoDiscriminator,
+		"$/definitions/Size/exclusiveMaximum": CodeDraftKeyword,
+		"$/definitions/Named/$ref":            CodeDraftKeyword,
+	})
+}
+
+func TestLintDraft04(t *testing.T) {
+	schema := `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"id": "https://example.com/shapes.json",
+		"definitions": {
+			"Circle": {"id": "#circle", "type": "object", "required": ["kind"], "properties": {"kind": {"enum": ["circle"]}}},
+			"Square": {"type": "object", "properties": {"kind": {"const": "square"}}}
+		},
+		"oneOf": [{"$ref": "#circle"}, {"$ref": "#/definitions/Square"}],
+		"minimum": 0,
+		"exclusiveMinimum": true
+	}`
+	r, root := newTestResolver(t, "https://example.com/other.json", schema, nil)
+	target, err := r.Resolve(root, "https://example.com/shapes.json#circle")
+	if err != nil {
+		t.Fatalf("Failed to resolve the draft-04 id anchor: %v", err)
+	}
+	if target.Pointer != "/definitions/Circle" {
+		t.Errorf("Expected /definitions/Circle, got %q", target.Pointer)
+	}
+
+	result, err := NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	var draftIssues []string
+	for _, issue := range result.Issues {
+		if issue.Code == CodeDraftKeyword {
+			draftIssues = append(draftIssues, issue.Path)
+		}
+	}
+	if strings.Join(draftIssues, ",") != "$/definitions/Square/properties/kind/const" {
+		t.Errorf("Expected only const to be reported in draft-04, got %v", draftIssues)
+	}
+}
+
+func TestDraftOverride(t *testing.T) {
+	schema := `{"type": "number", "exclusiveMaximum": 10}`
+
+	config := DefaultConfig()
+	config.Draft = Draft4
+	fixed, applied := lintAndFix(t, config, schema)
+	if applied != 1 {
+		t.Fatalf("Expected 1 fix, got %d", applied)
+	}
+	if want := `{"type": "number", "exclusiveMaximum": true, "maximum": 10}`; fixed != want {
+		t.Errorf("Expected %s, got %s", want, fixed)
+	}
+
+	if _, applied := lintAndFix(t, DefaultConfig(), schema); applied != 0 {
+		t.Errorf("Expected no fixes in 2020-12, got %d", applied)
+	}
+}
+
+func TestFixDraftKeywords(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{
+			name:   "array-form items",
+			schema: `{"type": "array", "items": [{"type": "string"}], "additionalItems": false}`,
+			want:   `{"type": "array", "prefixItems": [{"type": "string"}], "items": false}`,
+		},
+		{
+			name:   "boolean exclusive bound",
+			schema: `{"type": "integer", "minimum": 1, "exclusiveMinimum": true}`,
+			want:   `{"type": "integer", "exclusiveMinimum": 1}`,
+		},
+		{
+			name:   "false exclusive bound",
+			schema: `{"type": "integer", "maximum": 1, "exclusiveMaximum": false}`,
+			want:   `{"type": "integer", "maximum": 1}`,
+		},
+		{
+			name:   "legacy id",
+			schema: `{"id": "https://example.com/a.json", "type": "object"}`,
+			want:   `{"$id": "https://example.com/a.json", "type": "object"}`,
+		},
+		{
+			name:   "prefixItems before 2020-12",
+			schema: `{"$schema": "http://json-schema.org/draft-07/schema#", "prefixItems": [{"type": "string"}], "items": false}`,
+			want:   `{"$schema": "http://json-schema.org/draft-07/schema#", "items": [{"type": "string"}], "additionalItems": false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, _ := lintAndFix(t, DefaultConfig(), tt.schema)
+			if fixed != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, fixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package linter
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// StdinPath is the path argument that reads a schema from standard input.
+const StdinPath = "-"
+
+// stdinFile is the name issues are reported under for a schema read from stdin.
+const stdinFile = "<stdin>"
+
+// DefaultIncludePatterns selects the files linted when walking a directory.
+var DefaultIncludePatterns = []string{"*.json", "*.jsonc", "*.yaml", "*.yml"}
+
+// FindSchemaFiles expands paths into the list of schema files to lint.
+// Directories are walked recursively and yield the files matching include
+// (DefaultIncludePatterns if empty); files named explicitly are always
+// included. Any file or directory matching exclude is skipped, as are
+// configuration files (ConfigFileNames) found in directories. StdinPath is
+// passed through unchanged.
+//
+// Patterns without a "/" match a file's base name; other patterns match the
+// slash-separated path relative to the directory being walked (or the path
+// as given, for explicit files) and may use "**" to match any number of
+// directories.
+func FindSchemaFiles(paths, include, exclude []string) ([]string, error) {
+	if len(include) == 0 {
+		include = DefaultIncludePatterns
+	}
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	add := func(file string) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, p := range paths {
+		if p == StdinPath {
+			add(p)
+			continue
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if !matchAnyGlob(exclude, filepath.ToSlash(p)) {
+				add(p)
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(p, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(p, file)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if matchAnyGlob(exclude, rel) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.IsDir() && matchAnyGlob(include, rel) && !slices.Contains(ConfigFileNames, d.Name()) {
+				add(file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// matchAnyGlob reports whether name, a slash-separated path, matches any of patterns.
+func matchAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(name)); ok {
+				return true
+			}
+			continue
+		}
+		if matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a "**"
+// segment matches zero or more path segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
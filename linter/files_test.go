@@ -0,0 +1,134 @@
+package linter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindSchemaFiles(t *testing.T) {
+	refs := filepath.Join("..", "testdata", "refs")
+	shapes := filepath.Join(refs, "shapes.json")
+	circle := filepath.Join(refs, "common", "circle.json")
+	square := filepath.Join(refs, "common", "square.json")
+
+	tests := []struct {
+		name             string
+		paths            []string
+		include, exclude []string
+		want             []string
+	}{
+		{"directory", []string{refs}, nil, nil, []string{circle, square, shapes}},
+		{"include base name", []string{refs}, []string{"s*.json"}, nil, []string{square, shapes}},
+		{"include relative path", []string{refs}, []string{"common/*.json"}, nil, []string{circle, square}},
+		{"exclude directory", []string{refs}, nil, []string{"common"}, []string{shapes}},
+		{"exclude double star", []string{refs}, nil, []string{"**/c*.json"}, []string{square, shapes}},
+		{"explicit file and stdin", []string{shapes, StdinPath, shapes}, []string{"*.yaml"}, nil, []string{shapes, StdinPath}},
+		{"explicit file excluded", []string{shapes}, nil, []string{"shapes.json"}, nil},
+	}
+	for _, tt := range tests {
+		got, err := FindSchemaFiles(tt.paths, tt.include, tt.exclude)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	if _, err := FindSchemaFiles([]string{refs}, []string{"[*.json"}, nil); err == nil {
+		t.Error("Expected error for invalid glob")
+	}
+}
+
+func TestFindSchemaFilesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.yaml", "c.yml", "d.jsonc", "notes.txt", ".schemalint.json", ".schemalint.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := FindSchemaFiles([]string{dir}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range got {
+		names = append(names, filepath.Base(file))
+	}
+	if want := []string{"a.json", "b.yaml", "c.yml", "d.jsonc"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+}
+
+func TestLintFiles(t *testing.T) {
+	good := filepath.Join("..", "testdata", "good_schema.json")
+	bad := filepath.Join("..", "testdata", "bad_schema.json")
+
+	l := NewWithDefaults()
+	result := l.LintFiles([]string{good, bad, good}, 2)
+	if len(result.Results) != 3 || result.Results[0].SchemaPath != good || result.Results[1].SchemaPath != bad {
+		t.Fatalf("Expected results in input order, got: %v", result.Results)
+	}
+	if !result.HasErrors() || result.ErrorCount() != result.Results[1].ErrorCount() {
+		t.Errorf("Expected errors from %s only, got %d", bad, result.ErrorCount())
+	}
+
+	text := result.String()
+	if !strings.Contains(text, bad+"\n") || strings.Contains(text, good+"\n") {
+		t.Errorf("Expected issues grouped under files with issues only, got:\n%s", text)
+	}
+	if !strings.Contains(text, "3 file(s) checked, 1 with issues") {
+		t.Errorf("Expected multi-file summary, got:\n%s", text)
+	}
+}
+
+func TestLintFilesReportsFailures(t *testing.T) {
+	good := filepath.Join("..", "testdata", "good_schema.json")
+	missing := filepath.Join("..", "testdata", "missing.json")
+	broken := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(broken, []byte(`{"a": `), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewWithDefaults().LintFiles([]string{missing, broken, good}, 4)
+	if len(result.Results) != 3 || result.Results[2].SchemaPath != good || len(result.Results[2].Issues) != 0 {
+		t.Fatalf("Expected the readable file to be linted, got: %v", result.Results)
+	}
+	for _, failed := range result.Results[:2] {
+		if len(failed.Issues) != 1 || failed.Issues[0].Code != CodeInvalidSchema || failed.Issues[0].File != failed.SchemaPath {
+			t.Errorf("Expected one invalid-schema error for %s, got: %v", failed.SchemaPath, failed.Issues)
+		}
+	}
+	if result.ErrorCount() != 2 {
+		t.Errorf("Expected 2 errors, got %d", result.ErrorCount())
+	}
+}
+
+func TestLintFilesFailureOverrides(t *testing.T) {
+	missing := filepath.Join("..", "testdata", "missing.json")
+	good := filepath.Join("..", "testdata", "good_schema.json")
+
+	config := DefaultConfig()
+	config.Rules = map[IssueCode]Severity{CodeInvalidSchema: SeverityOff}
+	if result := New(config).LintFiles([]string{missing}, 1); len(result.Results[0].Issues) != 0 {
+		t.Errorf("Expected the disabled invalid-schema error to be dropped, got: %v", result.Results[0].Issues)
+	}
+
+	config.Rules[CodeInvalidSchema] = SeverityWarning
+	result := New(config).LintFiles([]string{missing, good}, 1)
+	if issues := result.Results[0].Issues; len(issues) != 1 || issues[0].Severity != SeverityWarning {
+		t.Errorf("Expected the invalid-schema issue as a warning, got: %v", issues)
+	}
+
+	// SARIF takes the rule metadata from the first result, here the failed file
+	results := []Result{*result.Results[0], *result.Results[1]}
+	for _, rule := range newSARIFLog(results).Runs[0].Tool.Driver.Rules {
+		if rule.ID == string(CodeInvalidSchema) && rule.DefaultConfiguration.Level != "warning" {
+			t.Errorf("Expected the SARIF rule to carry the override, got %+v", rule.DefaultConfiguration)
+		}
+	}
+}
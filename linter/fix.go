@@ -0,0 +1,412 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// PatchOperation is an RFC 6902 JSON Patch operation. Paths are JSON Pointers
+// into the document the issue was found in.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// FileFix is the outcome of applying fixes to one file.
+type FileFix struct {
+	File     string
+	Format   InputFormat
+	Original []byte
+	Fixed    []byte
+	// Applied is the number of fixes applied; Skipped counts fixes that
+	// conflicted with another fix or could not be applied.
+	Applied int
+	Skipped int
+}
+
+// Fix applies the fixes carried by the issues in results to the files the
+// issues were found in. Files are read from disk but not written. Schemas
+// read from standard input are not fixed, and neither are YAML files, whose
+// fixes are all counted as skipped. A fix reported by several results, as for
+// a file referenced from several linted files, is applied once.
+func Fix(results ...*Result) ([]FileFix, error) {
+	fixes := make(map[string][][]PatchOperation)
+	var files []string
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			file := issue.File
+			if file == "" {
+				file = result.SchemaPath
+			}
+			if len(issue.Fix) == 0 || file == "" || file == stdinFile {
+				continue
+			}
+			ops, _ := json.Marshal(issue.Fix)
+			key := strings.Join([]string{file, issue.Path, string(ops)}, "\x00")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := fixes[file]; !ok {
+				files = append(files, file)
+			}
+			fixes[file] = append(fixes[file], issue.Fix)
+		}
+	}
+
+	var fileFixes []FileFix
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		format := DetectInputFormat(file, data)
+		fixed, applied := data, 0
+		if format != FormatYAML {
+			fixed, applied = ApplyFixes(data, fixes[file])
+		}
+		fileFixes = append(fileFixes, FileFix{
+			File:     file,
+			Format:   format,
+			Original: data,
+			Fixed:    fixed,
+			Applied:  applied,
+			Skipped:  len(fixes[file]) - applied,
+		})
+	}
+	return fileFixes, nil
+}
+
+// ApplyFixes applies fixes to the JSON or JSONC document data and returns the
+// result along with the number of fixes applied. Fixes are applied as text
+// edits against the original document, so formatting and comments outside the
+// edited values are preserved; a fix that overlaps an earlier one or cannot be
+// expressed as a text edit is skipped.
+func ApplyFixes(data []byte, fixes [][]PatchOperation) ([]byte, int) {
+	// Edits are planned on the document with comments blanked out, which
+	// keeps every offset of the original
+	clean := stripJSONC(data)
+	m := buildSourceMap(clean)
+
+	var accepted []textEdit
+	applied := 0
+	for _, fix := range fixes {
+		edits, err := fixEdits(clean, m, fix)
+		if err != nil {
+			continue
+		}
+		candidate := append(slices.Clone(accepted), edits...)
+		if overlapping(candidate) {
+			continue
+		}
+		accepted = candidate
+		applied++
+	}
+
+	sort.Slice(accepted, func(i, j int) bool { return accepted[i].start < accepted[j].start })
+	var out bytes.Buffer
+	last := 0
+	for _, edit := range accepted {
+		out.Write(data[last:edit.start])
+		out.WriteString(edit.text)
+		last = edit.end
+	}
+	out.Write(data[last:])
+	return out.Bytes(), applied
+}
+
+// textEdit replaces data[start:end] with text.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// overlapping reports whether any two edits touch the same text.
+func overlapping(edits []textEdit) bool {
+	for i, a := range edits {
+		for _, b := range edits[i+1:] {
+			if a.start < b.end && b.start < a.end || a.start == b.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fixEdits translates the operations of one fix into text edits. Pointers
+// moved by an earlier operation of the fix are mapped back to their original
+// location, since all edits apply to the original document.
+func fixEdits(data []byte, m *sourceMap, ops []PatchOperation) ([]textEdit, error) {
+	type rename struct{ to, from string }
+	var renames []rename
+	vacated := make(map[string]bool) // original members renamed by the fix
+	original := func(pointer string) string {
+		for i := len(renames) - 1; i >= 0; i-- {
+			r := renames[i]
+			if pointer == r.to || strings.HasPrefix(pointer, r.to+"/") {
+				pointer = r.from + strings.TrimPrefix(pointer, r.to)
+			}
+		}
+		return pointer
+	}
+
+	var edits []textEdit
+	for _, op := range ops {
+		path := original(op.Path)
+		switch op.Op {
+		case "replace":
+			sp, ok := m.spans[path]
+			if !ok {
+				return nil, fmt.Errorf("replace: no value at %q", op.Path)
+			}
+			edits = append(edits, textEdit{sp.Start.Offset, sp.End.Offset, inlineJSON(op.Value)})
+
+		case "move":
+			// Only renames within the same object can be expressed in place
+			from := original(op.From)
+			parent, name := splitParent(op.Path)
+			fromParent, _ := splitParent(op.From)
+			sp, ok := m.spans[from]
+			if !ok || !sp.HasKey || original(parent) != original(fromParent) {
+				return nil, fmt.Errorf("move: unsupported from %q to %q", op.From, op.Path)
+			}
+			target := original(parent) + "/" + EscapePointerToken(name)
+			if _, exists := m.spans[target]; exists && !vacated[target] {
+				return nil, fmt.Errorf("move: %q already exists", op.Path)
+			}
+			key, _ := json.Marshal(name)
+			edits = append(edits, textEdit{sp.Key.Offset, stringEnd(data, sp.Key.Offset), string(key)})
+			renames = append(renames, rename{to: op.Path, from: from})
+			vacated[from] = true
+			delete(vacated, target)
+
+		case "remove":
+			edit, err := removeMember(data, m, path)
+			if err != nil {
+				return nil, err
+			}
+			edits = append(edits, edit)
+
+		case "add":
+			edit, err := addMember(data, m, path, op.Value)
+			if err != nil {
+				return nil, err
+			}
+			edits = append(edits, edit)
+
+		default:
+			return nil, fmt.Errorf("unsupported patch operation %q", op.Op)
+		}
+	}
+	return edits, nil
+}
+
+// splitParent splits a JSON Pointer into its parent pointer and unescaped last token.
+func splitParent(pointer string) (string, string) {
+	i := strings.LastIndex(pointer, "/")
+	if i < 0 {
+		return "", ""
+	}
+	return pointer[:i], UnescapePointerToken(pointer[i+1:])
+}
+
+// members returns the spans of the direct members of the value at pointer, in source order.
+func members(m *sourceMap, pointer string) []span {
+	var spans []span
+	for p, sp := range m.spans {
+		if rest, ok := strings.CutPrefix(p, pointer+"/"); ok && !strings.Contains(rest, "/") {
+			spans = append(spans, sp)
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start.Offset < spans[j].Start.Offset })
+	return spans
+}
+
+// removeMember removes the object member at pointer along with its separating comma.
+func removeMember(data []byte, m *sourceMap, pointer string) (textEdit, error) {
+	sp, ok := m.spans[pointer]
+	if !ok || !sp.HasKey {
+		return textEdit{}, fmt.Errorf("remove: no object member at %q", pointer)
+	}
+	parent, _ := splitParent(pointer)
+	siblings := members(m, parent)
+	for i, sibling := range siblings {
+		if sibling.Start.Offset != sp.Start.Offset {
+			continue
+		}
+		switch {
+		case i+1 < len(siblings):
+			return textEdit{sp.Key.Offset, siblings[i+1].Key.Offset, ""}, nil
+		case i > 0:
+			return textEdit{siblings[i-1].End.Offset, sp.End.Offset, ""}, nil
+		}
+	}
+	return textEdit{sp.Key.Offset, sp.End.Offset, ""}, nil
+}
+
+// addMember inserts a new member at pointer after the last member of its object.
+func addMember(data []byte, m *sourceMap, pointer string, value json.RawMessage) (textEdit, error) {
+	if _, exists := m.spans[pointer]; exists {
+		return textEdit{}, fmt.Errorf("add: %q already exists", pointer)
+	}
+	parent, name := splitParent(pointer)
+	sp, ok := m.spans[parent]
+	if !ok || data[sp.Start.Offset] != '{' {
+		return textEdit{}, fmt.Errorf("add: no object at %q", parent)
+	}
+	key, _ := json.Marshal(name)
+	member := string(key) + ": " + inlineJSON(value)
+
+	siblings := members(m, parent)
+	if len(siblings) == 0 {
+		return textEdit{sp.Start.Offset + 1, sp.Start.Offset + 1, member}, nil
+	}
+	last := siblings[len(siblings)-1]
+	separator := ", "
+	if first := siblings[0]; first.Key.Line > sp.Start.Line {
+		// One member per line: repeat the indentation of the first member
+		lineStart := bytes.LastIndexByte(data[:first.Key.Offset], '\n') + 1
+		separator = ",\n" + string(data[lineStart:first.Key.Offset])
+	}
+	return textEdit{last.End.Offset, last.End.Offset, separator + member}, nil
+}
+
+// stringEnd returns the offset just past the JSON string starting at start.
+func stringEnd(data []byte, start int) int {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// inlineJSON formats a JSON value on a single line with a space after each
+// ':' and ',', matching common hand-written style.
+func inlineJSON(value json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return string(value)
+	}
+	var sb strings.Builder
+	inString := false
+	data := compact.Bytes()
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		sb.WriteByte(c)
+		switch {
+		case inString && c == '\\':
+			i++
+			sb.WriteByte(data[i])
+		case c == '"':
+			inString = !inString
+		case !inString && (c == ':' || c == ','):
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
+
+// jsonValue encodes v for a patch operation.
+func jsonValue(v any) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+// renamePropertyFix returns the fix renaming property name of the schema at
+// pointer to newName, including its entries in required.
+func renamePropertyFix(schema *Schema, pointer, name, newName string) []PatchOperation {
+	properties := pointer + "/properties/"
+	fix := []PatchOperation{{
+		Op:   "move",
+		From: properties + EscapePointerToken(name),
+		Path: properties + EscapePointerToken(newName),
+	}}
+	for i, required := range schema.Required {
+		if required == name {
+			fix = append(fix, PatchOperation{
+				Op:    "replace",
+				Path:  fmt.Sprintf("%s/required/%d", pointer, i),
+				Value: jsonValue(newName),
+			})
+		}
+	}
+	return fix
+}
+
+// convertCase converts name to the property case convention, returning ""
+// when the name cannot be converted.
+func convertCase(name string, propertyCase PropertyCase) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	title := func(word string) string {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		return string(runes)
+	}
+
+	switch propertyCase {
+	case CaseCamel:
+		for i := 1; i < len(words); i++ {
+			words[i] = title(words[i])
+		}
+		return strings.Join(words, "")
+	case CasePascal:
+		for i := range words {
+			words[i] = title(words[i])
+		}
+		return strings.Join(words, "")
+	case CaseSnake:
+		return strings.Join(words, "_")
+	case CaseKebab:
+		return strings.Join(words, "-")
+	}
+	return ""
+}
+
+// splitWords splits an identifier into words at separators and case changes
+// ("HTTPServer_id" -> HTTP, Server, id).
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
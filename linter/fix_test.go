@@ -0,0 +1,198 @@
+package linter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// lintAndFix lints schema with config and applies every fix it reports.
+func lintAndFix(t *testing.T, config Config, schema string) (string, int) {
+	t.Helper()
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	var fixes [][]PatchOperation
+	for _, issue := range result.Issues {
+		if len(issue.Fix) > 0 {
+			fixes = append(fixes, issue.Fix)
+		}
+	}
+	fixed, applied := ApplyFixes([]byte(schema), fixes)
+	if !json.Valid(fixed) {
+		t.Fatalf("Fixed schema is not valid JSON:\n%s", fixed)
+	}
+	return string(fixed), applied
+}
+
+func TestFixPropertyCase(t *testing.T) {
+	schema := `{
+  "type": "object",
+  "required": ["user_name"],
+  "properties": {
+    "user_name": {"type": "string"},
+    "HTTPServer": {"properties": {"max-conns": {"type": "integer"}}},
+    "taken": {"type": "string"},
+    "Taken": {"type": "string"}
+  }
+}`
+	want := `{
+  "type": "object",
+  "required": ["userName"],
+  "properties": {
+    "userName": {"type": "string"},
+    "httpServer": {"properties": {"maxConns": {"type": "integer"}}},
+    "taken": {"type": "string"},
+    "Taken": {"type": "string"}
+  }
+}`
+
+	config := DefaultConfig()
+	config.PropertyCase = CaseCamel
+	fixed, applied := lintAndFix(t, config, schema)
+	if fixed != want {
+		t.Errorf("Unexpected fix:\n%s", fixed)
+	}
+	// "Taken" would collide with "taken" and is left alone
+	if applied != 3 {
+		t.Errorf("Expected 3 fixes applied, got %d", applied)
+	}
+}
+
+func TestFixVariantAdditionalProperties(t *testing.T) {
+	schema := `{"oneOf": [
+	{"properties": {"kind": {"const": "a"}}, "additionalProperties": true},
+	{"properties": {"kind": {"const": "b"}}, "additionalProperties": {"type": "string"}}
+]}`
+
+	fixed, applied := lintAndFix(t, DefaultConfig(), schema)
+	if applied != 1 {
+		t.Errorf("Expected only the boolean form to be fixed, got %d fixes", applied)
+	}
+	if !strings.Contains(fixed, `{"const": "a"}}, "additionalProperties": false}`) {
+		t.Errorf("Unexpected fix:\n%s", fixed)
+	}
+}
+
+func TestFixNullableTypeArray(t *testing.T) {
+	schema := `{"type": "object", "properties": {
+		"a": {"type": ["null", "integer"], "minimum": 0},
+		"b": {"type": ["string", "number"]}
+	}}`
+
+	config := DefaultConfig()
+	config.Profile = ProfileScale
+	fixed, applied := lintAndFix(t, config, schema)
+	if applied != 1 {
+		t.Errorf("Expected 1 fix applied, got %d", applied)
+	}
+	if !strings.Contains(fixed, `"a": {"anyOf": [{"type": "integer"}, {"type": "null"}], "minimum": 0}`) {
+		t.Errorf("Unexpected fix:\n%s", fixed)
+	}
+}
+
+func TestFixSharedReference(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"common.json": `{"$defs": {
+	"A": {"properties": {"kind": {"const": "a"}}, "additionalProperties": true},
+	"B": {"properties": {"kind": {"const": "b"}}}
+}}`,
+		"a.json": `{"oneOf": [{"$ref": "common.json#/$defs/A"}, {"$ref": "common.json#/$defs/B"}]}`,
+		"b.json": `{"oneOf": [{"$ref": "common.json#/$defs/A"}, {"$ref": "common.json#/$defs/B"}]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	multi := NewWithDefaults().LintFiles([]string{filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")}, 1)
+	fileFixes, err := Fix(multi.Results...)
+	if err != nil {
+		t.Fatalf("Failed to fix: %v", err)
+	}
+	if len(fileFixes) != 1 {
+		t.Fatalf("Expected fixes for common.json only, got %d files", len(fileFixes))
+	}
+	if ff := fileFixes[0]; ff.Applied != 1 || ff.Skipped != 0 {
+		t.Errorf("Expected 1 fix applied and none skipped, got %d applied and %d skipped", ff.Applied, ff.Skipped)
+	}
+}
+
+func TestApplyFixesAddRemove(t *testing.T) {
+	data := `{
+	"a": 1,
+	"b": {},
+	"c": 3
+}`
+	fixed, applied := ApplyFixes([]byte(data), [][]PatchOperation{
+		{{Op: "remove", Path: "/a"}},
+		{{Op: "add", Path: "/d", Value: json.RawMessage(`{"x":[1,2]}`)}},
+		{{Op: "add", Path: "/b/e", Value: json.RawMessage(`"s"`)}},
+		{{Op: "remove", Path: "/c"}, {Op: "replace", Path: "/c", Value: json.RawMessage(`4`)}}, // overlaps the first removal of /c
+		{{Op: "replace", Path: "/missing", Value: json.RawMessage(`1`)}},
+	})
+	want := `{
+	"b": {"e": "s"},
+	"c": 3,
+	"d": {"x": [1, 2]}
+}`
+	if string(fixed) != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, fixed)
+	}
+	if applied != 3 {
+		t.Errorf("Expected 3 fixes applied, got %d", applied)
+	}
+}
+
+func TestConvertCase(t *testing.T) {
+	tests := []struct {
+		name string
+		to   PropertyCase
+		want string
+	}{
+		{"user_name", CaseCamel, "userName"},
+		{"HTTPServer", CaseSnake, "http_server"},
+		{"userID2x", CaseKebab, "user-id2x"},
+		{"max-conns", CasePascal, "MaxConns"},
+		{"--", CaseCamel, ""},
+	}
+	for _, tt := range tests {
+		if got := convertCase(tt.name, tt.to); got != tt.want {
+			t.Errorf("convertCase(%q, %s) = %q, want %q", tt.name, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nL\nm"
+	want := `--- a/x.json
++++ b/x.json
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -9,4 +9,5 @@
+ i
+ j
+ k
+-l
++L
++m
+\ No newline at end of file
+`
+	if got := UnifiedDiff("x.json", []byte(old), []byte(new)); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+	if got := UnifiedDiff("x.json", []byte(old), []byte(old)); got != "" {
+		t.Errorf("Expected no diff for equal input, got:\n%s", got)
+	}
+}
@@ -0,0 +1,297 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// goKeywords are the Go keywords, which no identifier may be.
+var goKeywords = []string{
+	"break", "case", "chan", "const", "continue", "default", "defer", "else",
+	"fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+	"map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+}
+
+// goInitialisms are the words Go generators write in upper case, following
+// the Go naming conventions ("user_id" -> UserID).
+var goInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "URI",
+	"URL", "UTF8", "UUID", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// goIdentifier returns the exported Go identifier generators derive from a
+// property or definition name: its words capitalized and joined, with
+// initialisms in upper case ("foo_bar" and "fooBar" -> FooBar).
+func goIdentifier(name string) string {
+	var sb strings.Builder
+	for _, word := range splitWords(name) {
+		if upper := strings.ToUpper(word); slices.Contains(goInitialisms, upper) {
+			sb.WriteString(upper)
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+// goName returns the Go identifier of a property or definition: its
+// x-go-name extension, which generators such as oapi-codegen honour, or the
+// identifier derived from name.
+func goName(schema *Schema, name string) string {
+	if schema != nil {
+		var xGoName string
+		if raw, ok := schema.Extra["x-go-name"]; ok && json.Unmarshal(raw, &xGoName) == nil && xGoName != "" {
+			return xGoName
+		}
+	}
+	return goIdentifier(name)
+}
+
+// goMembers are the members of a schema keyword that become Go identifiers.
+type goMembers struct {
+	keyword string
+	kind    string // "Property" or "Definition", for messages
+	members map[string]*Schema
+	names   []string // in source order
+}
+
+// goNamedMembers returns the members of schema that become Go identifiers:
+// properties become struct fields and definitions become types.
+func goNamedMembers(schema *Schema) []goMembers {
+	var groups []goMembers
+	for _, g := range []goMembers{
+		{keyword: "properties", kind: "Property", members: schema.Properties},
+		{keyword: "$defs", kind: "Definition", members: schema.Defs},
+		{keyword: "definitions", kind: "Definition", members: schema.Definitions},
+	} {
+		if len(g.members) == 0 {
+			continue
+		}
+		g.names = schema.memberKeys[g.keyword]
+		if len(g.names) != len(g.members) {
+			g.names = sortedKeys(g.members)
+		}
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// checkGoIdentifierCollision reports properties, and definitions, whose
+// names become the same Go identifier, such as foo_bar and fooBar. A struct
+// with both fields does not compile, so generators rename one arbitrarily.
+func checkGoIdentifierCollision(ctx *RuleContext, node *Node) {
+	for _, g := range goNamedMembers(node.Schema) {
+		first := make(map[string]string) // identifier -> first name
+		for _, name := range g.names {
+			ident := goName(g.members[name], name)
+			if ident == "" {
+				continue
+			}
+			other, ok := first[ident]
+			if !ok {
+				first[ident] = name
+				continue
+			}
+			ctx.Report(Issue{
+				Path:       fmt.Sprintf("%s/%s/%s", node.Path, g.keyword, EscapePointerToken(name)),
+				Message:    fmt.Sprintf("%s '%s' becomes the Go identifier %s, as does '%s'", g.kind, name, ident, other),
+				Suggestion: "Rename one of them, or set its Go name with x-go-name",
+			})
+		}
+	}
+}
+
+// checkGoIdentifier reports property and definition names that do not make
+// valid Go identifiers: names starting with a digit, names with no letters
+// or digits, and Go keywords. Keywords are reported as info, since
+// generators export the identifier and only need to rename it where it
+// stays unexported, such as in parameters and local variables.
+func checkGoIdentifier(ctx *RuleContext, node *Node) {
+	for _, g := range goNamedMembers(node.Schema) {
+		for _, name := range g.names {
+			if member := g.members[name]; member != nil && member.Extra["x-go-name"] != nil {
+				continue
+			}
+			issue := Issue{Path: fmt.Sprintf("%s/%s/%s", node.Path, g.keyword, EscapePointerToken(name))}
+			ident := goIdentifier(name)
+			switch {
+			case ident == "":
+				issue.Message = fmt.Sprintf("%s '%s' has no letters or digits to form a Go identifier", g.kind, name)
+				issue.Suggestion = "Rename it, or set its Go name with x-go-name"
+			case unicode.IsDigit([]rune(ident)[0]):
+				issue.Message = fmt.Sprintf("%s '%s' starts with a digit, which a Go identifier cannot", g.kind, name)
+				issue.Suggestion = "Rename it, or set its Go name with x-go-name; generators otherwise prefix or drop the digits"
+			case slices.Contains(goKeywords, name):
+				issue.Severity = SeverityInfo
+				issue.Message = fmt.Sprintf("%s '%s' is a Go keyword", g.kind, name)
+				issue.Suggestion = fmt.Sprintf("Generators export it as %s, but must rename it where it stays unexported, such as in parameter names", ident)
+			default:
+				continue
+			}
+			ctx.Report(issue)
+		}
+	}
+}
+
+// goIntRanges are the ranges of the Go integer types selected by format.
+var goIntRanges = map[string][2]float64{
+	"int32":  {math.MinInt32, math.MaxInt32},
+	"int64":  {math.MinInt64, math.MaxInt64},
+	"uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxUint64},
+}
+
+// checkGoIntegerWidth reports integers that generators map to int, whose
+// size depends on the platform: integers without an int32 or int64 format
+// and without both a minimum and a maximum that fit in int32. It also
+// reports bounds outside the range of the integer's format.
+func checkGoIntegerWidth(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	if !slices.Contains(schema.types(), "integer") {
+		return
+	}
+	lower, lowerKeyword := schema.Minimum, "minimum"
+	if schema.ExclusiveMinimum != nil {
+		lower, lowerKeyword = schema.ExclusiveMinimum, "exclusiveMinimum"
+	}
+	upper, upperKeyword := schema.Maximum, "maximum"
+	if schema.ExclusiveMaximum != nil {
+		upper, upperKeyword = schema.ExclusiveMaximum, "exclusiveMaximum"
+	}
+
+	if limits, ok := goIntRanges[schema.Format]; ok {
+		for _, bound := range []struct {
+			keyword string
+			value   *json.Number
+		}{{lowerKeyword, lower}, {upperKeyword, upper}} {
+			if bound.value != nil && (numberValue(*bound.value) < limits[0] || numberValue(*bound.value) > limits[1]) {
+				ctx.Report(Issue{
+					Path:       node.Path + "/format",
+					Message:    fmt.Sprintf("%s %s is out of range for format %s", bound.keyword, *bound.value, schema.Format),
+					Suggestion: "Use a wider format, or a number or string type for values beyond 64 bits",
+				})
+				return
+			}
+		}
+		return
+	}
+	if schema.Format != "" && schema.Format != "int" && schema.Format != "uint" {
+		// An unknown format is the generator's business
+		return
+	}
+	if lower != nil && upper != nil && numberValue(*lower) >= math.MinInt32 && numberValue(*upper) <= math.MaxInt32 {
+		return
+	}
+
+	issue := Issue{
+		Path:       node.Path,
+		Message:    "integer has no int32 or int64 format or bounds; Go generators map it to int, whose size depends on the platform",
+		Suggestion: "Add format: int64 (or int32 with minimum and maximum)",
+	}
+	if schema.Format == "" {
+		issue.Fix = []PatchOperation{{Op: "add", Path: node.Pointer + "/format", Value: jsonValue("int64")}}
+	}
+	ctx.Report(issue)
+}
+
+// checkGoDefault reports default values encoding/json cannot decode into the
+// Go type generated for the schema: values of another JSON type (including
+// null for a non-nullable schema), integers that overflow the type or are
+// written with a fraction or exponent, and date-times that are not RFC 3339,
+// which time.Time requires.
+func checkGoDefault(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	raw, ok := schema.source["default"]
+	types := schema.types()
+	var value any
+	if !ok || len(types) == 0 || decodeJSON(raw, &value) != nil {
+		return
+	}
+	issue := Issue{Path: node.Path + "/default"}
+	switch valueType := jsonType(value); {
+	case valueType == "null":
+		if schema.IsNullable() {
+			return
+		}
+		issue.Message = fmt.Sprintf("default null cannot be decoded into %s, which is not a pointer", goType(schema))
+		issue.Suggestion = "Remove the default, or make the schema nullable"
+	case !slices.Contains(types, valueType) && !(valueType == "integer" && slices.Contains(types, "number")):
+		issue.Message = fmt.Sprintf("default %s is %s, which encoding/json cannot decode into %s", compactValue(value), describeTypes([]string{valueType}), goType(schema))
+		issue.Suggestion = fmt.Sprintf("Use a default of type %s", strings.Join(types, " or "))
+	case valueType == "integer" && goType(schema) != "interface{}" && !slices.Contains(types, "number"):
+		if goIntegerFits(string(value.(json.Number)), goType(schema)) {
+			return
+		}
+		issue.Message = fmt.Sprintf("default %s cannot be decoded into %s", value, goType(schema))
+		issue.Suggestion = "Write the default as an integer literal within the range of the format"
+	case valueType == "string" && schema.Format == "date-time":
+		if _, err := time.Parse(time.RFC3339, value.(string)); err == nil {
+			return
+		}
+		issue.Message = fmt.Sprintf("default %s is not an RFC 3339 date-time, which time.Time requires", compactValue(value))
+		issue.Suggestion = `Use a date-time such as "2006-01-02T15:04:05Z"`
+	default:
+		return
+	}
+	ctx.Report(issue)
+}
+
+// goIntegerFits reports whether encoding/json can decode the number literal
+// into the Go integer type, which rejects fractions and exponents even for
+// integral values such as 1.0.
+func goIntegerFits(literal, goType string) bool {
+	var err error
+	switch goType {
+	case "int32":
+		_, err = strconv.ParseInt(literal, 10, 32)
+	case "uint32":
+		_, err = strconv.ParseUint(literal, 10, 32)
+	case "uint64":
+		_, err = strconv.ParseUint(literal, 10, 64)
+	default:
+		_, err = strconv.ParseInt(literal, 10, 64)
+	}
+	return err == nil
+}
+
+// goType names the Go type generators typically use for schema.
+func goType(schema *Schema) string {
+	types := slices.DeleteFunc(slices.Clone(schema.types()), func(t string) bool { return t == "null" })
+	if len(types) != 1 {
+		return "interface{}"
+	}
+	switch types[0] {
+	case "integer":
+		if _, ok := goIntRanges[schema.Format]; ok {
+			return schema.Format
+		}
+		return "int"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "string":
+		if schema.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "a slice"
+	case "object":
+		return "a struct"
+	}
+	return "interface{}"
+}
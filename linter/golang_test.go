@@ -0,0 +1,92 @@
+package linter
+
+import (
+	"testing"
+)
+
+func TestGoIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"foo_bar":    "FooBar",
+		"fooBar":     "FooBar",
+		"FOO_BAR":    "FooBar",
+		"user_id":    "UserID",
+		"userId":     "UserID",
+		"api-url":    "APIURL",
+		"HTTPServer": "HTTPServer",
+		"2fa":        "2fa",
+		"$":          "",
+	}
+	for name, want := range tests {
+		if got := goIdentifier(name); got != want {
+			t.Errorf("goIdentifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGoProfile(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"foo_bar": {"type": "string"},
+			"fooBar": {"type": "string"},
+			"user_id": {"type": "string", "x-go-name": "OwnerID"},
+			"userId": {"type": "string"},
+			"2fa": {"type": "boolean"},
+			"type": {"type": "string"},
+			"count": {"type": "integer"},
+			"small": {"type": "integer", "minimum": 0, "maximum": 100},
+			"big": {"type": "integer", "format": "int32", "maximum": 3000000000},
+			"size": {"type": "integer", "format": "int64", "default": 1.0},
+			"ratio": {"type": "number", "default": "0.5"},
+			"name": {"type": "string", "default": null},
+			"nickname": {"type": ["string", "null"], "default": null},
+			"created": {"type": "string", "format": "date-time", "default": "2024-01-02"}
+		}
+	}`
+	config := DefaultConfig()
+	config.PropertyCase = CaseNone
+	config.Profile = ProfileGo
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/properties/fooBar":          CodeGoIdentifierCollision,
+		"$/properties/2fa":             CodeGoIdentifier,
+		"$/properties/type":            CodeGoIdentifier,
+		"$/properties/count":           CodeGoIntegerWidth,
+		"$/properties/big/format":      CodeGoIntegerWidth,
+		"$/properties/size/default":    CodeGoDefault,
+		"$/properties/ratio/default":   CodeGoDefault,
+		"$/properties/name/default":    CodeGoDefault,
+		"$/properties/created/default": CodeGoDefault,
+	})
+	for _, issue := range result.Issues {
+		if issue.Path == "$/properties/type" && issue.Severity != SeverityInfo {
+			t.Errorf("Expected Go keywords to be info, got %s", issue.Severity)
+		}
+	}
+
+	// Go checks only run in the go profile
+	config.Profile = ProfileDefault
+	result, err = New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected no issues in the default profile, got %v", result.Issues)
+	}
+}
+
+func TestFixGoIntegerWidth(t *testing.T) {
+	config := DefaultConfig()
+	config.Profile = ProfileGo
+	fixed, applied := lintAndFix(t, config, `{"type": "integer"}`)
+	if applied != 1 {
+		t.Fatalf("Expected 1 fix, got %d", applied)
+	}
+	if want := `{"type": "integer", "format": "int64"}`; fixed != want {
+		t.Errorf("Expected %s, got %s", want, fixed)
+	}
+}
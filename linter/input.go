@@ -0,0 +1,141 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+)
+
+// InputFormat is the syntax a schema document is written in.
+type InputFormat string
+
+const (
+	// FormatAuto detects the format from the file extension or content.
+	FormatAuto InputFormat = "auto"
+	// FormatJSON is plain JSON.
+	FormatJSON InputFormat = "json"
+	// FormatJSONC is JSON with // and /* */ comments and trailing commas.
+	FormatJSONC InputFormat = "jsonc"
+	// FormatYAML is YAML 1.2, as commonly used for OpenAPI documents.
+	FormatYAML InputFormat = "yaml"
+)
+
+// InputFormats returns all supported input formats.
+func InputFormats() []InputFormat {
+	return []InputFormat{FormatAuto, FormatJSON, FormatJSONC, FormatYAML}
+}
+
+// DetectInputFormat returns the format of data read from name. Files named
+// *.yaml, *.yml or *.jsonc are taken at their word; otherwise the content
+// decides: documents starting with '{' or '[' are JSON, or JSONC if they only
+// parse once comments are removed, and anything else is YAML.
+func DetectInputFormat(name string, data []byte) InputFormat {
+	switch strings.ToLower(path.Ext(stripFragment(name))) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".jsonc":
+		return FormatJSONC
+	}
+
+	trimmed := bytes.TrimSpace(stripJSONC(data))
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return FormatYAML
+	}
+	if !json.Valid(data) && json.Valid(stripJSONC(data)) {
+		return FormatJSONC
+	}
+	return FormatJSON
+}
+
+// decodeInput converts data in format to JSON. YAML documents also return
+// the source positions of their values, since these cannot be recovered from
+// the converted JSON; for JSON and JSONC the returned JSON has the same
+// layout as the source and the source map is nil.
+func decodeInput(data []byte, format InputFormat) ([]byte, *sourceMap, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil, errors.New("file is empty")
+	}
+	switch format {
+	case FormatYAML:
+		return yamlToJSON(data)
+	case FormatJSONC:
+		return stripJSONC(data), nil, nil
+	}
+	return data, nil, nil
+}
+
+// stripJSONC blanks out comments and trailing commas in JSONC data, keeping
+// every other byte (including newlines) in place so offsets, lines and
+// columns are unchanged.
+func stripJSONC(data []byte) []byte {
+	out := bytes.Clone(data)
+	blank := func(from, to int) {
+		for i := from; i < to; i++ {
+			if out[i] != '\n' && out[i] != '\r' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			end := bytes.IndexByte(out[i:], '\n')
+			if end < 0 {
+				end = len(out) - i
+			}
+			blank(i, i+end)
+			i += end - 1
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				// Unterminated comment: leave it for the JSON decoder to report
+				return out
+			}
+			blank(i, i+end+4)
+			i += end + 3
+		}
+	}
+
+	// Trailing commas, now that comments are blank
+	inString = false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			next := i + 1
+			for next < len(out) && isJSONSpace(out[next]) {
+				next++
+			}
+			if next < len(out) && (out[next] == '}' || out[next] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+	return out
+}
+
+// isJSONSpace reports whether c is JSON insignificant whitespace.
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,150 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDetectInputFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want InputFormat
+	}{
+		{"schema.json", `{"type": "object"}`, FormatJSON},
+		{"schema.yaml", `{"type": "object"}`, FormatYAML},
+		{"schema.YML", "type: object", FormatYAML},
+		{"schema.jsonc", `{"type": "object"}`, FormatJSONC},
+		{"schema.json", "// comment\n{\"type\": \"object\",}", FormatJSONC},
+		{"schema.json", "type: object", FormatYAML},
+		{"<stdin>", "  [1, 2]", FormatJSON},
+		{"<stdin>", "---\ntype: object", FormatYAML},
+		{"<stdin>", `{"url": "http://example.com"}`, FormatJSON},
+	}
+	for _, tt := range tests {
+		if got := DetectInputFormat(tt.name, []byte(tt.data)); got != tt.want {
+			t.Errorf("DetectInputFormat(%q, %q) = %s, want %s", tt.name, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	data := []byte(`{
+  // line comment
+  "url": "http://example.com/*x*/", /* block
+  comment */ "tags": ["a", "b",],
+}`)
+	stripped := stripJSONC(data)
+	if len(stripped) != len(data) {
+		t.Fatalf("Expected offsets to be kept, length changed from %d to %d", len(data), len(stripped))
+	}
+	if bytes.Count(stripped, []byte("\n")) != bytes.Count(data, []byte("\n")) {
+		t.Errorf("Expected newlines to be kept:\n%s", stripped)
+	}
+	if !bytes.Contains(stripped, []byte(`"http://example.com/*x*/"`)) {
+		t.Errorf("Expected strings to be left alone:\n%s", stripped)
+	}
+	if strings.Contains(string(stripped), "comment") {
+		t.Errorf("Expected comments to be removed:\n%s", stripped)
+	}
+	if !json.Valid(stripped) {
+		t.Errorf("Expected valid JSON:\n%s", stripped)
+	}
+}
+
+func TestLintYAML(t *testing.T) {
+	doc := `# Shapes
+$defs:
+  Shape:
+    oneOf:
+      - type: object
+      - type: array
+  Base: &base
+    type: object
+    properties:
+      id: {type: string}
+  Named:
+    <<: *base
+    properties:
+      Name: {type: string}
+`
+	config := DefaultConfig()
+	config.InputFormat = FormatYAML
+	result, err := New(config).Lint([]byte(doc))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/$defs/Shape/oneOf":           CodeUnionNoDiscriminator,
+		"$/$defs/Named/properties/Name": CodeInvalidPropertyCase,
+	})
+
+	// Line, column and end line of each issue in the YAML source
+	positions := map[string][3]int{
+		"$/$defs/Shape/oneOf":           {4, 5, 6},
+		"$/$defs/Named/properties/Name": {14, 7, 14},
+	}
+	for _, issue := range result.Issues {
+		if got := [3]int{issue.Line, issue.Column, issue.EndLine}; got != positions[issue.Path] {
+			t.Errorf("Expected %s at %v, got %v", issue.Path, positions[issue.Path], got)
+		}
+	}
+}
+
+func TestLintEmptyFile(t *testing.T) {
+	for _, data := range []string{"", " \n"} {
+		_, err := NewWithDefaults().Lint([]byte(data))
+		if err == nil || !strings.Contains(err.Error(), "file is empty") {
+			t.Errorf("Expected an empty file error for %q, got %v", data, err)
+		}
+	}
+}
+
+func TestLintJSONC(t *testing.T) {
+	doc := `{
+  // Shapes are objects or arrays
+  "oneOf": [
+    {"type": "object"}, /* no discriminator */
+    {"type": "array"},
+  ],
+}`
+	result, err := NewWithDefaults().Lint([]byte(doc))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeUnionNoDiscriminator {
+		t.Fatalf("Expected one union-no-discriminator issue, got %v", result.Issues)
+	}
+	if issue := result.Issues[0]; issue.Line != 3 || issue.Column != 3 || issue.EndLine != 6 {
+		t.Errorf("Expected the issue at 3:3-6, got %d:%d-%d", issue.Line, issue.Column, issue.EndLine)
+	}
+
+	config := DefaultConfig()
+	config.InputFormat = FormatJSON
+	if _, err := New(config).Lint([]byte(doc)); err == nil {
+		t.Error("Expected an error when JSONC is read as JSON")
+	}
+}
+
+func TestApplyFixesJSONC(t *testing.T) {
+	data := []byte(`{
+  // Keep me
+  "type": ["string", "null"], /* and me */
+}`)
+	fixed, applied := ApplyFixes(data, [][]PatchOperation{{
+		{Op: "replace", Path: "/type", Value: []byte(`"string"`)},
+	}})
+	if applied != 1 {
+		t.Fatalf("Expected 1 fix applied, got %d", applied)
+	}
+	want := `{
+  // Keep me
+  "type": "string", /* and me */
+}`
+	if string(fixed) != want {
+		t.Errorf("Unexpected result:\n%s", fixed)
+	}
+}
@@ -2,8 +2,12 @@
 package linter
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -14,8 +18,15 @@ const (
 	SeverityError   Severity = "error"
 	SeverityWarning Severity = "warning"
 	SeverityInfo    Severity = "info"
+	// SeverityOff disables an issue code when used as a rule override.
+	SeverityOff Severity = "off"
 )
 
+// Severities returns the severities accepted as rule overrides.
+func Severities() []Severity {
+	return []Severity{SeverityError, SeverityWarning, SeverityInfo, SeverityOff}
+}
+
 // IssueCode identifies a specific type of lint issue.
 type IssueCode string
 
@@ -26,6 +37,7 @@ const (
 	CodeMissingConst              IssueCode = "missing-const"
 	CodeDuplicateConstValue       IssueCode = "duplicate-const-value"
 	CodeInvalidPropertyCase       IssueCode = "invalid-property-case"
+	CodeInvalidSchema             IssueCode = "invalid-schema"
 
 	// Warnings - these may cause issues or indicate suboptimal patterns
 	CodeLargeUnion        IssueCode = "large-union"
@@ -33,28 +45,185 @@ const (
 	CodeAdditionalProps   IssueCode = "additional-properties"
 	CodeAmbiguousUnion    IssueCode = "ambiguous-union"
 	CodeCircularReference IssueCode = "circular-reference"
+	CodeUnusedSuppression IssueCode = "unused-suppression"
+	CodeNullableForm      IssueCode = "nullable-form"
+	CodeDraftKeyword      IssueCode = "draft-keyword"
 
 	// Scale profile errors - strict rules for static type compatibility
 	CodeCompositionDisallowed     IssueCode = "composition-disallowed"
 	CodeAdditionalPropsDisallowed IssueCode = "additional-properties-disallowed"
 	CodeMissingType               IssueCode = "missing-type"
 	CodeMixedTypeDisallowed       IssueCode = "mixed-type-disallowed"
+
+	// Go profile - names and values Go code generated for encoding/json cannot express
+	CodeGoIdentifierCollision IssueCode = "go-identifier-collision"
+	CodeGoIdentifier          IssueCode = "go-identifier"
+	CodeGoIntegerWidth        IssueCode = "go-integer-width"
+	CodeGoDefault             IssueCode = "go-default"
+
+	// TypeScript profile - unions TypeScript cannot narrow and types that lose precision
+	CodeTSOptionalDiscriminator IssueCode = "ts-optional-discriminator"
+	CodeTSIndexSignature        IssueCode = "ts-index-signature"
+	CodeTSPropertyQuoting       IssueCode = "ts-property-quoting"
+
+	// Rust profile - serde enum representations of unions
+	CodeRustSerdeRepresentation  IssueCode = "rust-serde-representation"
+	CodeRustUnrepresentableUnion IssueCode = "rust-unrepresentable-union"
+
+	// Protobuf profile - constructs with no proto3 equivalent
+	CodeProtoOneof          IssueCode = "proto-oneof"
+	CodeProtoMap            IssueCode = "proto-map"
+	CodeProtoNestedArray    IssueCode = "proto-nested-array"
+	CodeProtoNullableScalar IssueCode = "proto-nullable-scalar"
+	CodeProtoEnumZero       IssueCode = "proto-enum-zero"
+
+	// Python profile - Pydantic v2 models generated with datamodel-codegen
+	CodePythonDiscriminator IssueCode = "python-discriminator"
+	CodePythonPropertyName  IssueCode = "python-property-name"
+	CodePythonMixedEnum     IssueCode = "python-mixed-enum"
 )
 
+// codeInfo is the default severity and description of an issue code.
+type codeInfo struct {
+	code        IssueCode
+	severity    Severity
+	description string
+}
+
+// issueCodeInfo holds the default severity and description of each built-in issue code.
+var issueCodeInfo = []codeInfo{
+	{CodeUnionNoDiscriminator, SeverityError, "Union (anyOf/oneOf) has no discriminator field"},
+	{CodeInconsistentDiscriminator, SeverityError, "Union variants use different discriminator field names"},
+	{CodeMissingConst, SeverityError, "Union variant lacks a const value for the discriminator"},
+	{CodeDuplicateConstValue, SeverityError, "Multiple union variants have the same discriminator value"},
+	{CodeInvalidPropertyCase, SeverityError, "Property name does not follow the configured case convention"},
+	{CodeInvalidSchema, SeverityError, "Schema does not conform to its draft's metaschema"},
+	{CodeLargeUnion, SeverityWarning, "Union has more variants than the configured threshold"},
+	{CodeNestedUnion, SeverityWarning, "Union is nested deeper than the configured threshold"},
+	{CodeAdditionalProps, SeverityWarning, "Union variant has additionalProperties: true"},
+	{CodeAmbiguousUnion, SeverityWarning, "Union variants cannot be told apart when decoding"},
+	{CodeCircularReference, SeverityError, "Definitions reference each other in a cycle"},
+	{CodeUnusedSuppression, SeverityWarning, "x-schemalint-ignore entry suppresses no issues"},
+	{CodeNullableForm, SeverityWarning, "Nullability is expressed in a form that is not idiomatic for the document's dialect"},
+	{CodeDraftKeyword, SeverityWarning, "Keyword is not defined by the schema's draft or takes a different form in it"},
+	{CodeCompositionDisallowed, SeverityError, "Composition keywords (anyOf, oneOf, allOf) are disallowed in the scale profile"},
+	{CodeAdditionalPropsDisallowed, SeverityError, "additionalProperties: true is disallowed in the scale profile"},
+	{CodeMissingType, SeverityError, "Schema lacks an explicit type in the scale profile"},
+	{CodeMixedTypeDisallowed, SeverityError, "Type arrays like [\"string\", \"number\"] are disallowed in the scale profile"},
+	{CodeGoIdentifierCollision, SeverityError, "Property or definition names become the same Go identifier"},
+	{CodeGoIdentifier, SeverityWarning, "Property or definition name is not a valid Go identifier"},
+	{CodeGoIntegerWidth, SeverityWarning, "Integer has no format or bounds fixing its Go integer type"},
+	{CodeGoDefault, SeverityWarning, "Default value cannot be decoded into the Go type by encoding/json"},
+	{CodeTSOptionalDiscriminator, SeverityWarning, "Union variant does not require its discriminator, so TypeScript cannot narrow on it"},
+	{CodeTSIndexSignature, SeverityWarning, "additionalProperties next to properties degrades to a loosely typed index signature"},
+	{CodeTSPropertyQuoting, SeverityWarning, "Property name is not a JavaScript identifier and must be quoted"},
+	{CodeRustSerdeRepresentation, SeverityInfo, "Serde enum representation the union maps to"},
+	{CodeRustUnrepresentableUnion, SeverityError, "Union has no serde representation: untagged variants cannot be told apart"},
+	{CodeProtoOneof, SeverityError, "Union variant is an array or map, which a proto3 oneof cannot contain"},
+	{CodeProtoMap, SeverityError, "Map has keys or values a proto3 map cannot express"},
+	{CodeProtoNestedArray, SeverityError, "Array of arrays would be a repeated repeated field"},
+	{CodeProtoNullableScalar, SeverityWarning, "Nullable scalar needs a google.protobuf wrapper type"},
+	{CodeProtoEnumZero, SeverityWarning, "Enum's first value cannot serve as the proto3 zero value"},
+	{CodePythonDiscriminator, SeverityError, "Union variant does not require its discriminator, so Pydantic cannot use it as a Literal discriminator"},
+	{CodePythonPropertyName, SeverityWarning, "Property name is a Python keyword or shadows a Pydantic BaseModel attribute"},
+	{CodePythonMixedEnum, SeverityWarning, "Enum mixes value types, which a Python Enum cannot model"},
+}
+
+// lookupIssueCodeInfo returns the built-in metadata for code.
+func lookupIssueCodeInfo(code IssueCode) (codeInfo, bool) {
+	for _, info := range issueCodeInfo {
+		if info.code == code {
+			return info, true
+		}
+	}
+	return codeInfo{}, false
+}
+
+// IssueCodes returns all known issue codes: the built-in codes followed by
+// the IDs of registered custom rules.
+func IssueCodes() []IssueCode {
+	codes := make([]IssueCode, len(issueCodeInfo))
+	for i, info := range issueCodeInfo {
+		codes[i] = info.code
+	}
+	for _, rule := range Rules() {
+		if !slices.Contains(codes, rule.ID()) {
+			codes = append(codes, rule.ID())
+		}
+	}
+	return codes
+}
+
+// Description returns a short description of the issue code.
+func (c IssueCode) Description() string {
+	if info, ok := lookupIssueCodeInfo(c); ok {
+		return info.description
+	}
+	if rule, ok := lookupRule(c); ok {
+		return rule.Description()
+	}
+	return string(c)
+}
+
+// DefaultSeverity returns the severity the issue code is reported with by default.
+func (c IssueCode) DefaultSeverity() Severity {
+	if info, ok := lookupIssueCodeInfo(c); ok {
+		return info.severity
+	}
+	if rule, ok := lookupRule(c); ok {
+		return rule.DefaultSeverity()
+	}
+	return SeverityWarning
+}
+
 // Issue represents a single lint issue found in a schema.
 type Issue struct {
 	Code       IssueCode `json:"code"`
 	Severity   Severity  `json:"severity"`
+	File       string    `json:"file,omitempty"`
 	Path       string    `json:"path"`
+	Line       int       `json:"line,omitempty"`
+	Column     int       `json:"column,omitempty"`
+	EndLine    int       `json:"end_line,omitempty"`
 	Message    string    `json:"message"`
 	Suggestion string    `json:"suggestion,omitempty"`
 	TypeName   string    `json:"type_name,omitempty"`
+	Cycle      []string  `json:"cycle,omitempty"` // $ref chain for circular-reference issues
+	// Fix is a JSON Patch that resolves the issue, for issues with a safe
+	// mechanical fix. Its paths point into File (or the linted schema).
+	Fix []PatchOperation `json:"fix,omitempty"`
+}
+
+// Fingerprint returns a stable identifier for the issue, derived from its
+// code, cleaned file path and normalized path (see NormalizePath), for
+// matching results across runs.
+func (i Issue) Fingerprint() string {
+	return issueFingerprint(i.Code, cleanFile(i.File), i.Path)
+}
+
+// issueFingerprint hashes the parts of an issue that identify it across runs.
+func issueFingerprint(code IssueCode, file, path string) string {
+	sum := sha256.Sum256([]byte(string(code) + "\x00" + file + "\x00" + NormalizePath(path)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// cleanFile returns file as a clean slash-separated path, so that s.json and
+// ./s.json name the same file. Standard input and URIs are kept as given.
+func cleanFile(file string) string {
+	if file == "" || file == stdinFile || strings.Contains(file, "://") {
+		return file
+	}
+	return filepath.ToSlash(filepath.Clean(file))
 }
 
 // String returns a human-readable representation of the issue.
 func (i Issue) String() string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("[%s] %s: %s", i.Severity, i.Path, i.Message))
+	if i.Line > 0 {
+		sb.WriteString(fmt.Sprintf("[%s] %d:%d %s: %s", i.Severity, i.Line, i.Column, i.Path, i.Message))
+	} else {
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s", i.Severity, i.Path, i.Message))
+	}
 	if i.Suggestion != "" {
 		sb.WriteString(fmt.Sprintf("\n  suggestion: %s", i.Suggestion))
 	}
@@ -64,7 +233,12 @@ func (i Issue) String() string {
 // Result contains all issues found during linting.
 type Result struct {
 	SchemaPath string  `json:"schema_path"`
+	OpenAPI    string  `json:"openapi,omitempty"` // version, when the linted file is an OpenAPI document
+	Draft      Draft   `json:"draft,omitempty"`   // JSON Schema draft the file was linted as
 	Issues     []Issue `json:"issues"`
+
+	// rules holds the rule overrides in effect, for SARIF rule metadata.
+	rules map[IssueCode]Severity
 }
 
 // ErrorCount returns the number of error-severity issues.
@@ -111,27 +285,139 @@ func (r Result) String() string {
 		return sb.String()
 	}
 
-	for _, issue := range r.Issues {
-		sb.WriteString(issue.String())
-		sb.WriteString("\n")
-	}
+	r.writeIssues(&sb)
 
 	sb.WriteString(fmt.Sprintf("\nSummary: %d error(s), %d warning(s)\n", errors, warnings))
 
 	return sb.String()
 }
 
+// writeIssues writes one line per issue to sb.
+func (r Result) writeIssues(sb *strings.Builder) {
+	for _, issue := range r.Issues {
+		// Issues found in referenced documents name their file
+		if issue.File != "" && issue.File != r.SchemaPath {
+			sb.WriteString(issue.File + ": ")
+		}
+		sb.WriteString(issue.String())
+		sb.WriteString("\n")
+	}
+}
+
 // GitHubAnnotations returns issues formatted as GitHub Actions annotations.
 func (r Result) GitHubAnnotations() string {
 	var sb strings.Builder
+	r.writeAnnotations(&sb)
+	return sb.String()
+}
+
+// writeAnnotations writes one GitHub Actions annotation per issue to sb.
+func (r Result) writeAnnotations(sb *strings.Builder) {
 	for _, issue := range r.Issues {
-		// Format: ::{level} file={path}::{message}
+		// Format: ::{level} file={path},line={line},col={col},endLine={endLine}::{message}
 		level := "warning"
-		if issue.Severity == SeverityError {
+		switch issue.Severity {
+		case SeverityError:
 			level = "error"
+		case SeverityInfo:
+			level = "notice"
+		}
+		file := issue.File
+		if file == "" {
+			file = r.SchemaPath
+		}
+		location := "file=" + file
+		if issue.Line > 0 {
+			location += fmt.Sprintf(",line=%d,col=%d,endLine=%d", issue.Line, issue.Column, issue.EndLine)
 		}
-		sb.WriteString(fmt.Sprintf("::%s file=%s::%s - %s\n",
-			level, r.SchemaPath, issue.Code, issue.Message))
+		sb.WriteString(fmt.Sprintf("::%s %s::%s - %s\n",
+			level, location, issue.Code, issue.Message))
+	}
+}
+
+// MultiResult contains the results of linting several files.
+type MultiResult struct {
+	Results []*Result `json:"results"`
+}
+
+// ErrorCount returns the number of error-severity issues across all files.
+func (m MultiResult) ErrorCount() int {
+	count := 0
+	for _, r := range m.Results {
+		count += r.ErrorCount()
+	}
+	return count
+}
+
+// WarningCount returns the number of warning-severity issues across all files.
+func (m MultiResult) WarningCount() int {
+	count := 0
+	for _, r := range m.Results {
+		count += r.WarningCount()
+	}
+	return count
+}
+
+// HasErrors returns true if any file has error-severity issues.
+func (m MultiResult) HasErrors() bool {
+	return m.ErrorCount() > 0
+}
+
+// JSON returns all results as a single JSON document with a summary.
+func (m MultiResult) JSON() ([]byte, error) {
+	return json.MarshalIndent(struct {
+		Results      []*Result `json:"results"`
+		FileCount    int       `json:"file_count"`
+		ErrorCount   int       `json:"error_count"`
+		WarningCount int       `json:"warning_count"`
+	}{m.Results, len(m.Results), m.ErrorCount(), m.WarningCount()}, "", "  ")
+}
+
+// SARIF returns all results as a single SARIF 2.1.0 log.
+func (m MultiResult) SARIF() ([]byte, error) {
+	results := make([]Result, len(m.Results))
+	for i, r := range m.Results {
+		results[i] = *r
+	}
+	return json.MarshalIndent(newSARIFLog(results), "", "  ")
+}
+
+// String returns a human-readable summary with issues grouped by file.
+// Files without issues are omitted.
+func (m MultiResult) String() string {
+	if len(m.Results) == 1 {
+		return m.Results[0].String()
+	}
+
+	var sb strings.Builder
+	withIssues := 0
+	for _, r := range m.Results {
+		if len(r.Issues) == 0 {
+			continue
+		}
+		if withIssues > 0 {
+			sb.WriteString("\n")
+		}
+		withIssues++
+		sb.WriteString(r.SchemaPath + "\n")
+		r.writeIssues(&sb)
+	}
+
+	if withIssues == 0 {
+		sb.WriteString(fmt.Sprintf("✅ No issues found in %d file(s)\n", len(m.Results)))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("\nSummary: %d file(s) checked, %d with issues, %d error(s), %d warning(s)\n",
+		len(m.Results), withIssues, m.ErrorCount(), m.WarningCount()))
+	return sb.String()
+}
+
+// GitHubAnnotations returns the issues of all files as GitHub Actions annotations.
+func (m MultiResult) GitHubAnnotations() string {
+	var sb strings.Builder
+	for _, r := range m.Results {
+		r.writeAnnotations(&sb)
 	}
 	return sb.String()
 }
@@ -1,9 +1,11 @@
 package linter
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sync"
 )
 
 // Profile represents a linting profile with predefined rules.
@@ -14,6 +16,19 @@ const (
 	ProfileDefault Profile = "default"
 	// ProfileScale is a strict profile for static type compatibility (jsonschema4scale).
 	ProfileScale Profile = "scale"
+	// ProfileGo adds checks for Go code generated for encoding/json.
+	ProfileGo Profile = "go"
+	// ProfileTypeScript adds checks for TypeScript types, and accepts number
+	// and boolean const discriminators, which TypeScript narrows on.
+	ProfileTypeScript Profile = "typescript"
+	// ProfileRust maps unions to serde enum representations; unions serde
+	// can tell apart without a discriminator are not reported.
+	ProfileRust Profile = "rust"
+	// ProfileProtobuf adds checks for constructs with no proto3 equivalent.
+	ProfileProtobuf Profile = "protobuf"
+	// ProfilePython adds checks for Pydantic v2 models generated with
+	// datamodel-codegen.
+	ProfilePython Profile = "python"
 )
 
 // PropertyCase defines the casing convention for object properties.
@@ -32,18 +47,29 @@ const (
 	CasePascal PropertyCase = "PascalCase"
 )
 
-// Config holds linter configuration options.
+// Config holds linter configuration options. The struct tags define the keys
+// of the configuration file read by LoadConfig.
 type Config struct {
 	// Profile is the linting profile to use.
-	Profile Profile
+	Profile Profile `yaml:"profile" json:"profile"`
 	// PropertyCase is the casing convention to enforce for property names.
-	PropertyCase PropertyCase
+	PropertyCase PropertyCase `yaml:"property_case" json:"property_case"`
 	// MaxUnionVariants is the threshold for large union warnings (default: 10)
-	MaxUnionVariants int
+	MaxUnionVariants int `yaml:"max_union_variants" json:"max_union_variants"`
 	// MaxUnionNestingDepth is the threshold for nested union warnings (default: 2)
-	MaxUnionNestingDepth int
+	MaxUnionNestingDepth int `yaml:"max_union_nesting_depth" json:"max_union_nesting_depth"`
 	// DiscriminatorFields are the field names to look for as discriminators
-	DiscriminatorFields []string
+	DiscriminatorFields []string `yaml:"discriminator_fields" json:"discriminator_fields"`
+	// Rules overrides the severity of individual issue codes; SeverityOff disables them.
+	Rules map[IssueCode]Severity `yaml:"rules,omitempty" json:"rules,omitempty"`
+	// ReportUnusedSuppressions reports x-schemalint-ignore entries that suppress nothing.
+	ReportUnusedSuppressions bool `yaml:"report_unused_suppressions,omitempty" json:"report_unused_suppressions,omitempty"`
+	// Draft is the JSON Schema draft documents are written in. Empty or
+	// DraftAuto detects it from $schema (or the OpenAPI version) per document.
+	Draft Draft `yaml:"draft,omitempty" json:"draft,omitempty"`
+	// InputFormat is the format of the linted documents; documents they
+	// reference are always detected. Empty means FormatAuto.
+	InputFormat InputFormat `yaml:"input_format,omitempty" json:"input_format,omitempty"`
 }
 
 // DefaultConfig returns the default linter configuration.
@@ -54,9 +80,20 @@ func DefaultConfig() Config {
 		MaxUnionVariants:     10,
 		MaxUnionNestingDepth: 2,
 		DiscriminatorFields:  []string{"component_type", "type", "kind"},
+		Draft:                DraftAuto,
+		InputFormat:          FormatAuto,
 	}
 }
 
+// RuleSeverity returns the severity to report an issue with, applying any
+// override for code to the severity the check reported.
+func (c Config) RuleSeverity(code IssueCode, reported Severity) Severity {
+	if override, ok := c.Rules[code]; ok {
+		return override
+	}
+	return reported
+}
+
 // IsScaleProfile returns true if the scale profile is active.
 func (c Config) IsScaleProfile() bool {
 	return c.Profile == ProfileScale
@@ -77,14 +114,20 @@ func NewWithDefaults() *Linter {
 	return New(DefaultConfig())
 }
 
-// LintFile lints a JSON Schema file.
+// LintFile lints a JSON Schema file. External $refs are resolved relative to
+// the file's location (or its $id) and loaded from the filesystem.
 func (l *Linter) LintFile(path string) (*Result, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	result, err := l.Lint(data)
+	uri, err := FileURI(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	result, err := l.lint(data, uri, path, NewFileLoader(filepath.Dir(path)))
 	if err != nil {
 		return nil, err
 	}
@@ -92,470 +135,222 @@ func (l *Linter) LintFile(path string) (*Result, error) {
 	return result, nil
 }
 
-// Lint lints JSON Schema data.
-func (l *Linter) Lint(data []byte) (*Result, error) {
-	var schema Schema
-	if err := json.Unmarshal(data, &schema); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
-	}
-
-	result := &Result{
-		Issues: []Issue{},
+// LintReader lints a schema read from r and reports its issues under name.
+// External $refs are resolved relative to the working directory.
+func (l *Linter) LintReader(r io.Reader, name string) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
 	}
 
-	// Lint the root schema
-	l.lintSchema(&schema, "$", result, 0)
-
-	// Lint definitions ($defs)
-	for name, def := range schema.Defs {
-		path := fmt.Sprintf("$/$defs/%s", name)
-		l.lintSchema(def, path, result, 0)
+	uri, err := FileURI(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
 	}
 
-	// Lint legacy definitions (definitions)
-	for name, def := range schema.Definitions {
-		path := fmt.Sprintf("$/definitions/%s", name)
-		l.lintSchema(def, path, result, 0)
+	result, err := l.lint(data, uri, name, NewFileLoader("."))
+	if err != nil {
+		return nil, err
 	}
-
+	result.SchemaPath = name
 	return result, nil
 }
 
-func (l *Linter) lintSchema(schema *Schema, path string, result *Result, unionDepth int) {
-	if schema == nil {
-		return
-	}
-
-	// Scale profile: strict checks for static type compatibility
-	if l.config.IsScaleProfile() {
-		l.lintScaleProfile(schema, path, result)
-	}
-
-	// Check for union types
-	if len(schema.AnyOf) > 0 {
-		l.lintUnion(schema.AnyOf, path+"/anyOf", result, unionDepth, "anyOf")
-	}
-	if len(schema.OneOf) > 0 {
-		l.lintUnion(schema.OneOf, path+"/oneOf", result, unionDepth, "oneOf")
-	}
-
-	// Check properties
-	for propName, propSchema := range schema.Properties {
-		propPath := fmt.Sprintf("%s/properties/%s", path, propName)
-		l.lintSchema(propSchema, propPath, result, unionDepth)
-	}
-
-	// Check items
-	if schema.Items != nil {
-		l.lintSchema(schema.Items, path+"/items", result, unionDepth)
+// LintFiles lints paths concurrently using up to workers goroutines and
+// returns their results in the order given. StdinPath reads the schema from
+// standard input. A file that fails to lint, e.g. because it cannot be
+// parsed, is reported as a result with a single invalid-schema error, so the
+// other files' results are kept.
+func (l *Linter) LintFiles(paths []string, workers int) *MultiResult {
+	results := make([]*Result, len(paths))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(workers, len(paths))) {
+		wg.Go(func() {
+			for i := range jobs {
+				result, err := l.lintPath(paths[i])
+				if err != nil {
+					result = l.failedResult(paths[i], err)
+				}
+				results[i] = result
+			}
+		})
 	}
-
-	// Check additionalProperties
-	if schema.AdditionalPropertiesSchema != nil {
-		l.lintSchema(schema.AdditionalPropertiesSchema, path+"/additionalProperties", result, unionDepth)
+	for i := range paths {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	// Check property naming convention
-	if l.config.PropertyCase != CaseNone {
-		l.lintProperties(schema, path, result)
-	}
+	return &MultiResult{Results: results}
 }
 
-// lintProperties checks the casing of property names.
-func (l *Linter) lintProperties(schema *Schema, path string, result *Result) {
-	for propName := range schema.Properties {
-		isValid := false
-		switch l.config.PropertyCase {
-		case CaseCamel:
-			isValid = isCamelCase(propName)
-		case CaseSnake:
-			isValid = isSnakeCase(propName)
-		case CaseKebab:
-			isValid = isKebabCase(propName)
-		case CasePascal:
-			isValid = isPascalCase(propName)
-		}
-
-		if !isValid {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeInvalidPropertyCase,
-				Severity:   SeverityError,
-				Path:       fmt.Sprintf("%s/properties/%s", path, propName),
-				Message:    fmt.Sprintf("Property '%s' is not in %s", propName, l.config.PropertyCase),
-				Suggestion: fmt.Sprintf("Rename property to follow the %s convention", l.config.PropertyCase),
-			})
-		}
+// failedResult reports a file that could not be linted as an invalid-schema
+// error on its root, subject to the configured rule overrides.
+func (l *Linter) failedResult(path string, err error) *Result {
+	if path == StdinPath {
+		path = stdinFile
+	}
+	info, _ := lookupIssueCodeInfo(CodeInvalidSchema)
+	issues := []Issue{{
+		Code:     CodeInvalidSchema,
+		Severity: info.severity,
+		File:     path,
+		Path:     "$",
+		Message:  err.Error(),
+	}}
+	return &Result{
+		SchemaPath: path,
+		Issues:     l.applyRuleOverrides(issues),
+		rules:      l.config.Rules,
 	}
 }
 
-// isCamelCase checks if a string is in camelCase.
-func isCamelCase(s string) bool {
-	if s == "" {
-		return true
+// lintPath lints a file, or standard input for StdinPath.
+func (l *Linter) lintPath(path string) (*Result, error) {
+	if path == StdinPath {
+		return l.LintReader(os.Stdin, stdinFile)
 	}
-	if s[0] < 'a' || s[0] > 'z' {
-		return false
-	}
-	for _, r := range s {
-		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
-			return false
-		}
-	}
-	return true
+	return l.LintFile(path)
 }
 
-// isSnakeCase checks if a string is in snake_case.
-func isSnakeCase(s string) bool {
-	for _, r := range s {
-		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
-			return false
-		}
+// LintDocument lints the document at uri, loading it and any external $refs
+// through loader.
+func (l *Linter) LintDocument(uri string, loader Loader) (*Result, error) {
+	data, err := loader.Load(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document: %w", err)
 	}
-	return true
-}
 
-// isKebabCase checks if a string is in kebab-case.
-func isKebabCase(s string) bool {
-	for _, r := range s {
-		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
-			return false
-		}
-	}
-	return true
-}
-
-// isPascalCase checks if a string is in PascalCase.
-func isPascalCase(s string) bool {
-	if s == "" {
-		return true
-	}
-	if s[0] < 'A' || s[0] > 'Z' {
-		return false
-	}
-	for _, r := range s {
-		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
-			return false
-		}
+	result, err := l.lint(data, uri, uri, loader)
+	if err != nil {
+		return nil, err
 	}
-	return true
+	result.SchemaPath = uri
+	return result, nil
 }
 
-// lintScaleProfile applies strict checks for the scale profile.
-func (l *Linter) lintScaleProfile(schema *Schema, path string, result *Result) {
-	// Disallow composition keywords (anyOf, oneOf, allOf)
-	if len(schema.AnyOf) > 0 {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeCompositionDisallowed,
-			Severity:   SeverityError,
-			Path:       path + "/anyOf",
-			Message:    "anyOf is disallowed in scale profile",
-			Suggestion: "Use separate schema definitions instead of unions",
-		})
-	}
-	if len(schema.OneOf) > 0 {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeCompositionDisallowed,
-			Severity:   SeverityError,
-			Path:       path + "/oneOf",
-			Message:    "oneOf is disallowed in scale profile",
-			Suggestion: "Use separate schema definitions instead of unions",
-		})
-	}
-	if len(schema.AllOf) > 0 {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeCompositionDisallowed,
-			Severity:   SeverityError,
-			Path:       path + "/allOf",
-			Message:    "allOf is disallowed in scale profile",
-			Suggestion: "Flatten the schema structure instead of using composition",
-		})
-	}
-
-	// Disallow additionalProperties: true
-	if schema.AdditionalProperties != nil && *schema.AdditionalProperties {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeAdditionalPropsDisallowed,
-			Severity:   SeverityError,
-			Path:       path,
-			Message:    "additionalProperties: true is disallowed in scale profile",
-			Suggestion: "Set additionalProperties: false or remove it to ensure strict type mapping",
-		})
-	}
-
-	// Require explicit type (unless it's a $ref or boolean schema or container)
-	if !schema.HasType() && !schema.IsRef() && !schema.IsBooleanSchema {
-		// Only report if this is a meaningful schema (has properties, items, etc.)
-		if len(schema.Properties) > 0 || schema.Items != nil || schema.Const != nil || len(schema.Enum) > 0 {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeMissingType,
-				Severity:   SeverityError,
-				Path:       path,
-				Message:    "missing explicit type field in scale profile",
-				Suggestion: "Add a 'type' field to specify the schema type",
-			})
-		}
-	}
-
-	// Disallow mixed types (type arrays like ["string", "number"])
-	if schema.HasMixedType() {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeMixedTypeDisallowed,
-			Severity:   SeverityError,
-			Path:       path,
-			Message:    fmt.Sprintf("mixed type array %v is disallowed in scale profile", schema.TypeList),
-			Suggestion: "Use a single type; for nullable types, use a separate null check",
-		})
-	}
+// Lint lints JSON Schema data. Only local $refs can be resolved.
+func (l *Linter) Lint(data []byte) (*Result, error) {
+	return l.lint(data, "", "", nil)
 }
 
-func (l *Linter) lintUnion(variants []*Schema, path string, result *Result, unionDepth int, unionType string) {
-	// Skip nullable patterns (anyOf with null)
-	if l.isNullablePattern(variants) {
-		return
-	}
-
-	// Skip if all variants are $refs (need resolution to verify discriminators)
-	if l.allRefs(variants) {
-		return
-	}
-
-	// Check union size
-	if len(variants) > l.config.MaxUnionVariants {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeLargeUnion,
-			Severity:   SeverityWarning,
-			Path:       path,
-			Message:    fmt.Sprintf("Union has %d variants (threshold: %d)", len(variants), l.config.MaxUnionVariants),
-			Suggestion: "Consider splitting into smaller, more focused unions",
-		})
-	}
-
-	// Check nesting depth
-	if unionDepth >= l.config.MaxUnionNestingDepth {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeNestedUnion,
-			Severity:   SeverityWarning,
-			Path:       path,
-			Message:    fmt.Sprintf("Union nested %d levels deep (threshold: %d)", unionDepth+1, l.config.MaxUnionNestingDepth),
-			Suggestion: "Flatten the union hierarchy for better Go compatibility",
-		})
-	}
-
-	// Check for discriminator
-	discriminator := l.findDiscriminator(variants)
-	if discriminator == nil && len(variants) > 1 && !l.isReferencePattern(variants) {
-		result.Issues = append(result.Issues, Issue{
-			Code:       CodeUnionNoDiscriminator,
-			Severity:   SeverityError,
-			Path:       path,
-			Message:    fmt.Sprintf("%s union has no discriminator field", unionType),
-			Suggestion: "Add a const property (e.g., 'type' or 'kind') to each variant with a unique value",
-		})
+func (l *Linter) lint(data []byte, uri, file string, loader Loader) (*Result, error) {
+	resolver := NewResolver(loader)
+	resolver.draft = l.config.Draft
+	doc, err := resolver.addDocument(uri, file, data, l.config.InputFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
 	}
 
-	// If we found a discriminator, verify all variants have it
-	if discriminator != nil {
-		l.verifyDiscriminator(variants, discriminator, path, result)
+	result := &Result{
+		Issues:  doc.metaschemaIssues(),
+		OpenAPI: doc.openAPI,
+		Draft:   doc.draft,
 	}
-
-	// Check for additionalProperties on union variants
-	for i, variant := range variants {
-		if variant == nil || variant.Ref != "" {
-			continue
-		}
-		if variant.AdditionalProperties != nil && *variant.AdditionalProperties {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeAdditionalProps,
-				Severity:   SeverityWarning,
-				Path:       fmt.Sprintf("%s/%d", path, i),
-				Message:    "Union variant has additionalProperties: true",
-				Suggestion: "Set additionalProperties: false to avoid ambiguous JSON decoding",
-			})
-		}
+	ctx := newRuleContext(l.config, resolver, result)
+	rules := rulesFor(l.config.Profile)
+	for _, ds := range doc.schemas {
+		lintSchema(ctx, rules, ds)
 	}
 
-	// Recursively lint nested schemas in variants
-	for i, variant := range variants {
-		if variant != nil && variant.Ref == "" {
-			variantPath := fmt.Sprintf("%s/%d", path, i)
-			l.lintSchema(variant, variantPath, result, unionDepth+1)
+	// Documents loaded for $refs are validated too
+	for _, uri := range sortedKeys(resolver.docs) {
+		if other := resolver.docs[uri]; other != doc {
+			result.Issues = append(result.Issues, other.metaschemaIssues()...)
 		}
 	}
-}
 
-// allRefs checks if all variants are $ref references.
-func (l *Linter) allRefs(variants []*Schema) bool {
-	for _, v := range variants {
-		if v == nil {
-			continue
-		}
-		if v.Ref == "" {
-			return false
+	// Issues without a file live in the linted document itself
+	for i := range result.Issues {
+		if result.Issues[i].File == "" {
+			result.Issues[i].File = file
 		}
 	}
-	return true
-}
+	result.Issues = withoutCoveredViolations(result.Issues)
 
-// isNullablePattern checks if this is a simple nullable pattern: anyOf [T, null]
-func (l *Linter) isNullablePattern(variants []*Schema) bool {
-	if len(variants) != 2 {
-		return false
-	}
-	hasNull := false
-	hasType := false
-	for _, v := range variants {
-		if v == nil {
-			continue
-		}
-		if v.Type == "null" {
-			hasNull = true
-		} else if v.Type != "" || v.Ref != "" {
-			hasType = true
-		}
-	}
-	return hasNull && hasType
-}
+	root, _ := resolver.document(file)
+	result.Issues = applySuppressions(result.Issues, resolver, root, l.config.ReportUnusedSuppressions)
+	result.Issues = l.applyRuleOverrides(result.Issues)
+	result.rules = l.config.Rules
 
-// isReferencePattern checks if this is a reference pattern: anyOf [ComponentReference, BaseXxx]
-func (l *Linter) isReferencePattern(variants []*Schema) bool {
-	if len(variants) != 2 {
-		return false
-	}
-	for _, v := range variants {
-		if v == nil {
-			continue
-		}
-		// Check if one variant is a reference type (has $component_ref property)
-		if prop, ok := v.Properties["$component_ref"]; ok && prop != nil {
-			return true
-		}
-		// Check if it's a $ref to something with "Reference" in the name
-		if v.Ref != "" && (contains(v.Ref, "Reference") || contains(v.Ref, "Ref")) {
-			return true
+	for i := range result.Issues {
+		if doc, ok := resolver.document(result.Issues[i].File); ok {
+			locateIssue(&result.Issues[i], doc.sourceMap())
 		}
 	}
-	return false
-}
 
-// findDiscriminator looks for a common discriminator field across variants.
-func (l *Linter) findDiscriminator(variants []*Schema) *discriminatorInfo {
-	if len(variants) < 2 {
-		return nil
-	}
+	result.Issues = dedupeIssues(result.Issues)
 
-	// Count const values for each potential discriminator field
-	candidates := make(map[string]map[string]int) // field -> const value -> count
-
-	for _, fieldName := range l.config.DiscriminatorFields {
-		candidates[fieldName] = make(map[string]int)
-	}
-
-	resolvedVariants := 0
-	for _, variant := range variants {
-		if variant == nil || variant.Ref != "" {
-			// Skip $ref variants - they need to be resolved
-			continue
-		}
-		resolvedVariants++
-
-		for _, fieldName := range l.config.DiscriminatorFields {
-			if prop, ok := variant.Properties[fieldName]; ok && prop != nil {
-				if prop.Const != nil {
-					if strVal, ok := prop.Const.(string); ok {
-						candidates[fieldName][strVal]++
-					}
-				}
-			}
-		}
-	}
+	return result, nil
+}
 
-	// Find a field where all resolved variants have unique const values
-	for _, fieldName := range l.config.DiscriminatorFields {
-		values := candidates[fieldName]
-		if len(values) == resolvedVariants && resolvedVariants > 0 {
-			// Check all values are unique (count == 1)
-			allUnique := true
-			for _, count := range values {
-				if count != 1 {
-					allUnique = false
-					break
-				}
+// lintSchema runs the rules against a root schema of a document and every
+// subschema nested in it. $ref targets are linted where they are defined.
+func lintSchema(ctx *RuleContext, rules []Rule, root documentSchema) {
+	unionDepth := make(map[*Schema]int)
+	_ = Walk(root.schema, func(v Visit) error {
+		depth := 0
+		switch v.Keyword {
+		case "":
+		case "$defs", "definitions":
+			// Definitions are independent types
+		case "anyOf", "oneOf":
+			// A nullable pattern does not count as a union
+			variants := v.Parent.AnyOf
+			if v.Keyword == "oneOf" {
+				variants = v.Parent.OneOf
 			}
-			if allUnique {
-				return &discriminatorInfo{
-					fieldName: fieldName,
-					values:    values,
-				}
+			depth = unionDepth[v.Parent]
+			if !isNullablePattern(variants) {
+				depth++
 			}
+		default:
+			depth = unionDepth[v.Parent]
 		}
-	}
-
-	return nil
-}
+		unionDepth[v.Schema] = depth
 
-type discriminatorInfo struct {
-	fieldName string
-	values    map[string]int
+		ctx.check(rules, &Node{Visit: v, Path: "$" + v.Pointer, UnionDepth: depth})
+		return nil
+	}, walkAt(root.pointer))
 }
 
-func (l *Linter) verifyDiscriminator(variants []*Schema, disc *discriminatorInfo, path string, result *Result) {
-	seenValues := make(map[string]bool)
-
-	for i, variant := range variants {
-		if variant == nil || variant.Ref != "" {
-			continue
-		}
-
-		prop, ok := variant.Properties[disc.fieldName]
-		if !ok || prop == nil {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeMissingConst,
-				Severity:   SeverityError,
-				Path:       fmt.Sprintf("%s/%d", path, i),
-				Message:    fmt.Sprintf("Variant missing discriminator property '%s'", disc.fieldName),
-				Suggestion: fmt.Sprintf("Add '%s' property with a const value to this variant", disc.fieldName),
-			})
-			continue
-		}
-
-		if prop.Const == nil {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeMissingConst,
-				Severity:   SeverityError,
-				Path:       fmt.Sprintf("%s/%d/properties/%s", path, i, disc.fieldName),
-				Message:    fmt.Sprintf("Discriminator property '%s' has no const value", disc.fieldName),
-				Suggestion: fmt.Sprintf("Add 'const' to the '%s' property with a unique string value", disc.fieldName),
-			})
-			continue
-		}
-
-		strVal, ok := prop.Const.(string)
-		if !ok {
+// applyRuleOverrides applies the configured rule severities, dropping disabled issues.
+func (l *Linter) applyRuleOverrides(issues []Issue) []Issue {
+	if len(l.config.Rules) == 0 {
+		return issues
+	}
+	kept := issues[:0]
+	for _, issue := range issues {
+		issue.Severity = l.config.RuleSeverity(issue.Code, issue.Severity)
+		if issue.Severity == SeverityOff {
 			continue
 		}
-
-		if seenValues[strVal] {
-			result.Issues = append(result.Issues, Issue{
-				Code:       CodeDuplicateConstValue,
-				Severity:   SeverityError,
-				Path:       fmt.Sprintf("%s/%d/properties/%s", path, i, disc.fieldName),
-				Message:    fmt.Sprintf("Duplicate discriminator value '%s'", strVal),
-				Suggestion: "Each variant must have a unique const value for the discriminator",
-			})
-		}
-		seenValues[strVal] = true
+		kept = append(kept, issue)
 	}
+	return kept
 }
 
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsImpl(s, substr))
-}
-
-func containsImpl(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// dedupeIssues removes identical issues, which arise when a $ref target is
+// checked by more than one union.
+func dedupeIssues(issues []Issue) []Issue {
+	type issueKey struct {
+		code     IssueCode
+		severity Severity
+		file     string
+		path     string
+		message  string
+	}
+	seen := make(map[issueKey]bool, len(issues))
+	deduped := issues[:0]
+	for _, issue := range issues {
+		key := issueKey{issue.Code, issue.Severity, issue.File, issue.Path, issue.Message}
+		if seen[key] {
+			continue
 		}
+		seen[key] = true
+		deduped = append(deduped, issue)
 	}
-	return false
+	return deduped
 }
@@ -1,9 +1,29 @@
 package linter
 
 import (
+	"encoding/json"
+	"maps"
+	"strings"
 	"testing"
 )
 
+// assertIssues checks that result has exactly the issues in want, a map of
+// issue paths to codes.
+func assertIssues(t *testing.T, result *Result, want map[string]IssueCode) {
+	t.Helper()
+	want = maps.Clone(want)
+	for _, issue := range result.Issues {
+		if code, ok := want[issue.Path]; !ok || code != issue.Code {
+			t.Errorf("Unexpected issue: %v", issue)
+			continue
+		}
+		delete(want, issue.Path)
+	}
+	for path, code := range want {
+		t.Errorf("Expected %s at %s", code, path)
+	}
+}
+
 func TestLintNullablePattern(t *testing.T) {
 	schema := `{
 		"$defs": {
@@ -106,6 +126,43 @@ func TestLintUnionWithoutDiscriminator(t *testing.T) {
 	}
 }
 
+func TestLintReferencePattern(t *testing.T) {
+	tests := []struct {
+		name   string
+		union  string
+		exempt bool
+	}{
+		{"reference schema", `[{"$ref": "#/$defs/ComponentReference"}, {"$ref": "#/$defs/Charge"}]`, true},
+		{"component ref property", `[{"type": "object", "properties": {"$component_ref": {"type": "string"}}}, {"$ref": "#/$defs/Charge"}]`, true},
+		{"name containing Ref", `[{"$ref": "#/$defs/Refund"}, {"$ref": "#/$defs/Charge"}]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := `{
+				"$defs": {
+					"ComponentReference": {"type": "object", "properties": {"id": {"type": "string"}}},
+					"Refund": {"type": "object", "properties": {"amount": {"type": "number"}}},
+					"Charge": {"type": "object", "properties": {"total": {"type": "number"}}}
+				},
+				"anyOf": ` + tt.union + `
+			}`
+			result, err := NewWithDefaults().Lint([]byte(schema))
+			if err != nil {
+				t.Fatalf("Failed to lint: %v", err)
+			}
+			found := false
+			for _, issue := range result.Issues {
+				if issue.Code == CodeUnionNoDiscriminator {
+					found = true
+				}
+			}
+			if found == tt.exempt {
+				t.Errorf("Expected union-no-discriminator: %v, got %v", !tt.exempt, result.Issues)
+			}
+		})
+	}
+}
+
 func TestLintLargeUnion(t *testing.T) {
 	schema := `{
 		"$defs": {
@@ -217,7 +274,7 @@ func TestLintAllRefs(t *testing.T) {
 		t.Fatalf("Failed to lint: %v", err)
 	}
 
-	// Unions with all $refs should be skipped (no error for Animal)
+	// $ref variants are resolved; Dog and Cat are discriminated by type
 	for _, issue := range result.Issues {
 		if issue.Path == "$/$defs/Animal/anyOf" && issue.Code == CodeUnionNoDiscriminator {
 			t.Error("Should not report error for all-refs union")
@@ -492,3 +549,296 @@ func TestScaleProfileValidSchema(t *testing.T) {
 		t.Errorf("Expected no errors for valid scale profile schema, got: %v", result.Issues)
 	}
 }
+
+func TestRuleOverrides(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"BadUnion": {
+				"anyOf": [
+					{"type": "object", "properties": {"name": {"type": "string"}}},
+					{"type": "object", "properties": {"title": {"type": "string"}}, "additionalProperties": true}
+				]
+			}
+		}
+	}`
+
+	config := DefaultConfig()
+	config.Rules = map[IssueCode]Severity{
+		CodeUnionNoDiscriminator: SeverityWarning,
+		CodeAdditionalProps:      SeverityOff,
+	}
+	l := New(config)
+
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	if result.HasErrors() {
+		t.Errorf("Expected union-no-discriminator to be demoted, got: %v", result.Issues)
+	}
+	for _, issue := range result.Issues {
+		if issue.Code == CodeAdditionalProps {
+			t.Errorf("Expected additional-properties to be disabled, got: %v", issue)
+		}
+	}
+	if result.WarningCount() != 1 {
+		t.Errorf("Expected 1 warning, got %d", result.WarningCount())
+	}
+
+	data, err := result.SARIF()
+	if err != nil {
+		t.Fatalf("Failed to build SARIF: %v", err)
+	}
+	if !strings.Contains(string(data), `"enabled": false`) {
+		t.Error("Expected disabled rule in SARIF rule metadata")
+	}
+}
+
+func TestLintsAllSubschemas(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Extended": {
+				"allOf": [
+					{"$ref": "#/$defs/Base"},
+					{
+						"properties": {
+							"payload": {
+								"anyOf": [
+									{"type": "object", "properties": {"name": {"type": "string"}}},
+									{"type": "object", "properties": {"title": {"type": "string"}}}
+								]
+							}
+						}
+					}
+				]
+			},
+			"Base": {"type": "object"}
+		}
+	}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	if len(result.Issues) != 1 || result.Issues[0].Path != "$/$defs/Extended/allOf/1/properties/payload/anyOf" {
+		t.Errorf("Expected union inside allOf to be linted, got: %v", result.Issues)
+	}
+}
+
+func TestParseSchemaKeywords(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"type": "integer",
+		"format": "int64",
+		"minimum": 0,
+		"exclusiveMaximum": 100,
+		"dependentRequired": {"a": ["b"]},
+		"$anchor": "count",
+		"properties": {"legacy": {"maximum": 5, "exclusiveMaximum": true}}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if schema.Format != "int64" || schema.Anchor != "count" || len(schema.DependentRequired["a"]) != 1 {
+		t.Errorf("Unexpected schema: %+v", schema)
+	}
+	if schema.Minimum == nil || *schema.Minimum != "0" || schema.ExclusiveMaximum == nil || *schema.ExclusiveMaximum != "100" {
+		t.Errorf("Expected numeric bounds, got minimum %v, exclusiveMaximum %v", schema.Minimum, schema.ExclusiveMaximum)
+	}
+	if legacy := schema.Properties["legacy"]; legacy.ExclusiveMaximum != nil {
+		t.Errorf("Expected boolean exclusiveMaximum to be ignored, got %v", *legacy.ExclusiveMaximum)
+	}
+}
+
+func TestLintNumbersBeyondFloat64(t *testing.T) {
+	schema := `{"type": "integer", "format": "int64", "minimum": -1e400, "exclusiveMaximum": 1e400}`
+	config := DefaultConfig()
+	config.Profile = ProfileGo
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeGoIntegerWidth || !strings.Contains(result.Issues[0].Message, "minimum -1e400") {
+		t.Errorf("Expected the out-of-range bound to be reported as written, got %v", result.Issues)
+	}
+
+	parsed, err := ParseSchema([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if out, err := json.Marshal(parsed); err != nil || !strings.Contains(string(out), `"exclusiveMaximum":1e400`) {
+		t.Errorf("Expected the bound to round-trip, got %s (%v)", out, err)
+	}
+}
+
+func TestLintsUnionsUnderApplicators(t *testing.T) {
+	union := `{"anyOf": [
+		{"type": "object", "properties": {"name": {"type": "string"}}},
+		{"type": "object", "properties": {"title": {"type": "string"}}}
+	]}`
+	schema := `{
+		"patternProperties": {"^x-": ` + union + `},
+		"prefixItems": [` + union + `],
+		"if": {"required": ["kind"]},
+		"then": ` + union + `
+	}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	paths := make(map[string]bool)
+	for _, issue := range result.Issues {
+		paths[issue.Path] = true
+	}
+	for _, want := range []string{"$/patternProperties/^x-/anyOf", "$/prefixItems/0/anyOf", "$/then/anyOf"} {
+		if !paths[want] {
+			t.Errorf("Expected issue at %s, got: %v", want, result.Issues)
+		}
+	}
+}
+
+func TestNullableForm(t *testing.T) {
+	openAPI30 := func(schema string) string {
+		return `{"openapi": "3.0.3", "components": {"schemas": {"S": ` + schema + `}}}`
+	}
+	openAPI31 := func(schema string) string {
+		return `{"openapi": "3.1.0", "components": {"schemas": {"S": ` + schema + `}}}`
+	}
+	swagger2 := func(schema string) string {
+		return `{"swagger": "2.0", "definitions": {"S": ` + schema + `}}`
+	}
+
+	tests := []struct {
+		name    string
+		doc     string
+		profile Profile
+		want    []string // paths of nullable-form issues
+		fixed   string   // expected document after applying fixes, if any
+	}{
+		{
+			name:  "json schema nullable keyword",
+			doc:   `{"type": "string", "nullable": true}`,
+			want:  []string{"$/nullable"},
+			fixed: `{"type": ["string", "null"]}`,
+		},
+		{
+			name:  "json schema x-nullable on nullable type",
+			doc:   `{"type": ["string", "null"], "x-nullable": true}`,
+			want:  []string{"$/x-nullable"},
+			fixed: `{"type": ["string", "null"]}`,
+		},
+		{
+			name:    "scale suggests anyOf",
+			doc:     `{"type": "string", "nullable": true}`,
+			profile: ProfileScale,
+			want:    []string{"$/nullable"},
+		},
+		{
+			name: "json schema idiomatic forms",
+			doc:  `{"properties": {"a": {"type": ["string", "null"]}, "b": {"anyOf": [{"$ref": "#/$defs/B"}, {"type": "null"}]}}, "$defs": {"B": {"type": "object"}}}`,
+		},
+		{
+			name: "openapi 3.0 nullable keyword",
+			doc:  openAPI30(`{"type": "string", "nullable": true}`),
+		},
+		{
+			name:  "openapi 3.0 x-nullable",
+			doc:   openAPI30(`{"type": "string", "x-nullable": true}`),
+			want:  []string{"$/components/schemas/S/x-nullable"},
+			fixed: openAPI30(`{"type": "string", "nullable": true}`),
+		},
+		{
+			name:  "openapi 3.0 null type",
+			doc:   openAPI30(`{"type": ["null", "integer"]}`),
+			want:  []string{"$/components/schemas/S/type"},
+			fixed: openAPI30(`{"type": "integer", "nullable": true}`),
+		},
+		{
+			name: "openapi 3.0 nullable anyOf",
+			doc:  openAPI30(`{"anyOf": [{"type": "string"}, {"type": "null"}]}`),
+			want: []string{"$/components/schemas/S/anyOf"},
+		},
+		{
+			name: "swagger 2 x-nullable",
+			doc:  swagger2(`{"type": "string", "x-nullable": true}`),
+		},
+		{
+			name:  "swagger 2 nullable keyword",
+			doc:   swagger2(`{"type": "string", "nullable": true}`),
+			want:  []string{"$/definitions/S/nullable"},
+			fixed: swagger2(`{"type": "string", "x-nullable": true}`),
+		},
+		{
+			name:  "swagger 2 null type",
+			doc:   swagger2(`{"type": ["string", "null"]}`),
+			want:  []string{"$/definitions/S/type"},
+			fixed: swagger2(`{"type": "string", "x-nullable": true}`),
+		},
+		{
+			name: "openapi 3.1 nullable keyword",
+			doc:  openAPI31(`{"type": "string", "nullable": true}`),
+			want: []string{"$/components/schemas/S/nullable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			if tt.profile != "" {
+				config.Profile = tt.profile
+			}
+			result, err := New(config).Lint([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("Failed to lint: %v", err)
+			}
+			var got []string
+			var fixes [][]PatchOperation
+			for _, issue := range result.Issues {
+				if issue.Code == CodeNullableForm {
+					got = append(got, issue.Path)
+					if len(issue.Fix) > 0 {
+						fixes = append(fixes, issue.Fix)
+					}
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected nullable-form issues at %v, got %v", tt.want, got)
+			}
+			if tt.fixed != "" {
+				if fixed, _ := ApplyFixes([]byte(tt.doc), fixes); string(fixed) != tt.fixed {
+					t.Errorf("Expected fix %s, got %s", tt.fixed, fixed)
+				}
+			}
+		})
+	}
+}
+
+func TestScaleProfileAllowsNullablePatterns(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"a": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+			"b": {"nullable": true, "allOf": [{"$ref": "#/$defs/B"}]}
+		},
+		"$defs": {"B": {"type": "object", "additionalProperties": false}},
+		"additionalProperties": false
+	}`
+
+	config := DefaultConfig()
+	config.Profile = ProfileScale
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	for _, issue := range result.Issues {
+		if issue.Code == CodeCompositionDisallowed {
+			t.Errorf("Nullable pattern reported as composition: %v", issue)
+		}
+	}
+}
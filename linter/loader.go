@@ -0,0 +1,103 @@
+package linter
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Loader loads schema documents referenced by $ref.
+type Loader interface {
+	// Load returns the raw contents of the document at uri.
+	Load(uri string) ([]byte, error)
+}
+
+// FileLoader loads documents from the local filesystem. Relative URIs are
+// resolved against Root, which is typically the directory of the schema being linted.
+type FileLoader struct {
+	Root string
+}
+
+// NewFileLoader creates a FileLoader rooted at dir.
+func NewFileLoader(dir string) *FileLoader {
+	return &FileLoader{Root: dir}
+}
+
+// Load reads the document at a file:// or relative URI.
+func (f *FileLoader) Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URI %q: %w", uri, err)
+	}
+
+	var path string
+	switch u.Scheme {
+	case "file":
+		path = filePathFromURL(u)
+	case "":
+		path = filepath.Join(f.Root, filepath.FromSlash(u.Path))
+	default:
+		return nil, fmt.Errorf("unsupported URI scheme %q in %q", u.Scheme, uri)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// MapLoader is an in-memory Loader keyed by URI, mainly useful for tests.
+type MapLoader map[string][]byte
+
+// Load returns the document registered for uri.
+func (m MapLoader) Load(uri string) ([]byte, error) {
+	data, ok := m[uri]
+	if !ok {
+		return nil, fmt.Errorf("document not found: %s", uri)
+	}
+	return data, nil
+}
+
+// FileURI returns the absolute file:// URI for a filesystem path.
+func FileURI(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	slashed := filepath.ToSlash(abs)
+	if !strings.HasPrefix(slashed, "/") {
+		// Windows drive paths such as C:/dir
+		slashed = "/" + slashed
+	}
+	u := url.URL{Scheme: "file", Path: slashed}
+	return u.String(), nil
+}
+
+// filePathFromURL converts a file:// URL into a local filesystem path.
+func filePathFromURL(u *url.URL) string {
+	path := u.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		// Windows drive paths such as /C:/dir
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// uriFile returns the name used to report issues in the document at uri:
+// a path relative to the working directory for local files, otherwise the URI.
+func uriFile(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := filePathFromURL(u)
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
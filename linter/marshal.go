@@ -0,0 +1,332 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// schemaKeywords lists the keywords modelled by Schema, in field order. It is
+// the order used for keywords that did not appear in the parsed document.
+var schemaKeywords = func() []string {
+	// Fields tagged json:"-" that are decoded specially
+	special := map[string]string{
+		"Type":                 "type",
+		"Properties":           "properties",
+		"AdditionalProperties": "additionalProperties",
+		"Items":                "items",
+		"ExclusiveMinimum":     "exclusiveMinimum",
+		"ExclusiveMaximum":     "exclusiveMaximum",
+		"Dependencies":         "dependencies",
+		"Ignore":               IgnoreKeyword,
+	}
+	var keywords []string
+	t := reflect.TypeOf(Schema{})
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			name = special[field.Name]
+		}
+		if name != "" {
+			keywords = append(keywords, name)
+		}
+	}
+	return keywords
+}()
+
+// memberKeywords are the keywords whose values are objects with arbitrary member names.
+var memberKeywords = []string{"$defs", "definitions", "properties", "patternProperties", "dependentSchemas", "dependentRequired", "dependencies"}
+
+// subschemaKeywords are the keywords whose values are (or contain) schemas.
+var subschemaKeywords = []string{
+	"$defs", "definitions", "properties", "patternProperties", "additionalProperties",
+	"propertyNames", "unevaluatedProperties", "dependentSchemas", "dependencies", "prefixItems", "items",
+	"additionalItems", "contains", "unevaluatedItems", "allOf", "anyOf", "oneOf", "not", "if", "then", "else",
+}
+
+// setExtra records the value of an unmodelled keyword.
+func (s *Schema) setExtra(keyword string, value json.RawMessage) {
+	if s.Extra == nil {
+		s.Extra = make(map[string]json.RawMessage)
+	}
+	s.Extra[keyword] = value
+}
+
+// recordSource keeps what MarshalJSON needs to reproduce the parsed object:
+// key order, member order of map-valued keywords, the source encoding of
+// keyword values, and unmodelled keywords in Extra.
+func (s *Schema) recordSource(data []byte, raw map[string]json.RawMessage) {
+	s.keys = objectKeys(data)
+	for _, keyword := range memberKeywords {
+		if value, ok := raw[keyword]; ok {
+			if s.memberKeys == nil {
+				s.memberKeys = make(map[string][]string)
+			}
+			s.memberKeys[keyword] = objectKeys(value)
+		}
+	}
+
+	for keyword, value := range raw {
+		if !slices.Contains(schemaKeywords, keyword) {
+			s.setExtra(keyword, value)
+			continue
+		}
+		// Subschemas reproduce themselves; keep only leaf values
+		if !slices.Contains(subschemaKeywords, keyword) || (keyword == "additionalProperties" && s.AdditionalPropertiesSchema == nil) {
+			if s.source == nil {
+				s.source = make(map[string]json.RawMessage)
+			}
+			s.source[keyword] = value
+		}
+	}
+}
+
+// MarshalJSON encodes the schema as JSON. A parsed schema is written back
+// faithfully: keywords and members keep their source order, unchanged values
+// keep their source encoding (e.g. number formatting), and Extra keywords are
+// included. Keywords added programmatically follow in field order.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.IsBooleanSchema {
+		return json.Marshal(s.BooleanValue)
+	}
+
+	// Use an alias to avoid infinite recursion
+	type schemaAlias Schema
+	data, err := json.Marshal((*schemaAlias)(s))
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	// Keywords decoded specially
+	special := make(map[string]any)
+	switch {
+	case len(s.TypeList) > 1 || (len(s.TypeList) == 1 && s.TypeList[0] == s.Type):
+		special["type"] = s.TypeList
+	case s.Type != "":
+		special["type"] = s.Type
+	}
+	if s.AdditionalPropertiesSchema != nil {
+		special["additionalProperties"] = s.AdditionalPropertiesSchema
+	} else if s.AdditionalProperties != nil {
+		special["additionalProperties"] = *s.AdditionalProperties
+	}
+	if s.Items != nil {
+		special["items"] = s.Items
+	} else if s.ItemsList != nil {
+		special["items"] = s.ItemsList
+	}
+	if s.ExclusiveMinimum != nil {
+		special["exclusiveMinimum"] = *s.ExclusiveMinimum
+	} else if s.ExclusiveMinimumFlag != nil {
+		special["exclusiveMinimum"] = *s.ExclusiveMinimumFlag
+	}
+	if s.ExclusiveMaximum != nil {
+		special["exclusiveMaximum"] = *s.ExclusiveMaximum
+	} else if s.ExclusiveMaximumFlag != nil {
+		special["exclusiveMaximum"] = *s.ExclusiveMaximumFlag
+	}
+	if s.Ignore != nil {
+		special[IgnoreKeyword] = s.Ignore
+	}
+	for keyword, value := range special {
+		if values[keyword], err = json.Marshal(value); err != nil {
+			return nil, err
+		}
+	}
+
+	// Map-valued keywords keep their member order
+	if err := errors.Join(
+		setMembers(values, "$defs", s.Defs, s.memberKeys["$defs"]),
+		setMembers(values, "definitions", s.Definitions, s.memberKeys["definitions"]),
+		setMembers(values, "properties", s.Properties, s.memberKeys["properties"]),
+		setMembers(values, "patternProperties", s.PatternProperties, s.memberKeys["patternProperties"]),
+		setMembers(values, "dependentSchemas", s.DependentSchemas, s.memberKeys["dependentSchemas"]),
+		setMembers(values, "dependentRequired", s.DependentRequired, s.memberKeys["dependentRequired"]),
+		setMembers(values, "dependencies", s.dependencies(), s.memberKeys["dependencies"]),
+	); err != nil {
+		return nil, err
+	}
+
+	for keyword, value := range s.Extra {
+		if _, ok := values[keyword]; !ok {
+			values[keyword] = value
+		}
+	}
+
+	for keyword, src := range s.source {
+		value, ok := values[keyword]
+		switch {
+		case ok && jsonEqual(value, src):
+			// Unchanged: keep the source encoding
+			values[keyword] = src
+		case !ok && isEmptyJSON(src):
+			// Empty values such as false or [] are dropped by omitempty
+			values[keyword] = src
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, keyword := range s.orderedKeys(values) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(keyword)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(values[keyword])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// dependencies returns the members of dependencies in either form.
+func (s *Schema) dependencies() map[string]any {
+	if len(s.Dependencies) == 0 && len(s.DependenciesRequired) == 0 {
+		return nil
+	}
+	members := make(map[string]any, len(s.Dependencies)+len(s.DependenciesRequired))
+	for name, schema := range s.Dependencies {
+		members[name] = schema
+	}
+	for name, names := range s.DependenciesRequired {
+		members[name] = names
+	}
+	return members
+}
+
+// orderedKeys returns the keys of values in source order, followed by new
+// modelled keywords in field order and new extra keywords sorted by name.
+func (s *Schema) orderedKeys(values map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	add := func(key string) {
+		if _, ok := values[key]; ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range s.keys {
+		add(key)
+	}
+	for _, key := range schemaKeywords {
+		add(key)
+	}
+	var rest []string
+	for key := range values {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// setMembers encodes m into values[keyword] with its members in order, then
+// any members not listed in order sorted by name. Empty maps are omitted.
+func setMembers[V any](values map[string]json.RawMessage, keyword string, m map[string]V, order []string) error {
+	if len(m) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(m))
+	for _, name := range order {
+		if _, ok := m[name]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	var rest []string
+	for name := range m {
+		if !slices.Contains(names, name) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	names = append(names, rest...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(m[name])
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	values[keyword] = buf.Bytes()
+	return nil
+}
+
+// objectKeys returns the member names of a JSON object in source order.
+func objectKeys(data []byte) []string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return keys
+		}
+		if key, ok := tok.(string); ok && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return keys
+		}
+	}
+	return keys
+}
+
+// jsonEqual reports whether two JSON values are semantically equal.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// isEmptyJSON reports whether a JSON value is one omitempty leaves out.
+func isEmptyJSON(data json.RawMessage) bool {
+	var v any
+	if json.Unmarshal(data, &v) != nil {
+		return false
+	}
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, data)
+	}
+	return buf.String()
+}
+
+func TestSchemaRoundTrip(t *testing.T) {
+	data := `{
+		"title": "Order",
+		"x-go-type": {"import": "example.com/order"},
+		"type": ["object"],
+		"required": [],
+		"properties": {
+			"zeta": {"type": "number", "minimum": 1.0, "maximum": 1e3, "examples": [2.50]},
+			"alpha": {"const": null, "x-schemalint-ignore": ["missing-const"]},
+			"id": {"type": "integer", "exclusiveMaximum": true, "maximum": 10, "format": "int64"}
+		},
+		"additionalProperties": false,
+		"uniqueItems": false,
+		"prefixItems": [true, {"enum": ["a", 1, false]}],
+		"items": [{"type": "string"}, false],
+		"additionalItems": {"id": "#extra"},
+		"$defs": {
+			"B": {"$anchor": "b", "deprecated": true},
+			"A": {"dependentRequired": {"y": ["x"], "x": ["y"]}},
+			"C": {"dependencies": {"y": ["x"], "x": {"required": ["y"]}}}
+		},
+		"$comment": "kept"
+	}`
+
+	schema, err := ParseSchema([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	out, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if got, want := compactJSON(t, out), compactJSON(t, []byte(data)); got != want {
+		t.Errorf("Round trip changed the document:\nwant %s\ngot  %s", want, got)
+	}
+
+	if string(schema.Extra["x-go-type"]) != `{"import": "example.com/order"}` {
+		t.Errorf("Expected unmodelled keyword in Extra, got %s", schema.Extra["x-go-type"])
+	}
+	if flag := schema.Properties["id"].ExclusiveMaximumFlag; flag == nil || !*flag {
+		t.Error("Expected boolean exclusiveMaximum in ExclusiveMaximumFlag")
+	}
+	if len(schema.ItemsList) != 2 || schema.Items != nil {
+		t.Errorf("Expected array-form items in ItemsList, got %v", schema.ItemsList)
+	}
+}
+
+func TestSchemaMarshalModified(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{"type": "object", "x-b": 1, "properties": {"b": {"type": "string"}, "a": {"type": "string"}}, "required": ["a"]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	schema.Required = nil
+	schema.Properties["c"] = &Schema{Type: "integer"}
+	additional := false
+	schema.AdditionalProperties = &additional
+	schema.Title = "T"
+
+	out, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"type":"object","x-b":1,"properties":{"b":{"type":"string"},"a":{"type":"string"},"c":{"type":"integer"}},"additionalProperties":false,"title":"T"}`
+	if got := compactJSON(t, out); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestSchemaMarshalBoolean(t *testing.T) {
+	out, err := json.Marshal(&Schema{IsBooleanSchema: true})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(out) != "false" {
+		t.Errorf("Expected false, got %s", out)
+	}
+}
@@ -0,0 +1,590 @@
+package linter
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/big"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed metaschema
+var metaschemaFiles embed.FS
+
+// Metaschema URIs of the drafts whose metaschemas are embedded.
+const (
+	metaschemaDraft7 = "http://json-schema.org/draft-07/schema"
+	metaschema2020   = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// metaschemaFor returns the URI of the metaschema that schemas of draft are
+// validated against, or "" if none is embedded. Draft-06 schemas are valid
+// draft-07 schemas; draft-04 and 2019-09 are not validated.
+func metaschemaFor(draft Draft) string {
+	switch draft {
+	case Draft6, Draft7:
+		return metaschemaDraft7
+	case Draft2020:
+		return metaschema2020
+	}
+	return ""
+}
+
+// violation is a place where a schema does not conform to its metaschema.
+type violation struct {
+	pointer string // JSON Pointer into the validated document
+	message string
+	types   []string // the expected types, for type mismatches
+	// mismatch is set when the value has the wrong type altogether, such
+	// as a number where only certain strings are allowed
+	mismatch bool
+}
+
+// loadMetaschemas parses the embedded metaschemas once.
+var loadMetaschemas = sync.OnceValues(func() (*metaValidator, error) {
+	v := &metaValidator{
+		resources: make(map[string]map[string]any),
+		compiled:  make(map[string]*regexp.Regexp),
+	}
+	err := fs.WalkDir(metaschemaFiles, "metaschema", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := metaschemaFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var schema map[string]any
+		if err := decodeJSON(data, &schema); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		id, _ := schema["$id"].(string)
+		v.resources[stripFragment(id)] = schema
+		return nil
+	})
+	return v, err
+})
+
+// validateDocument validates the root schemas of doc against the metaschema
+// of its draft and returns the violations, sorted by pointer.
+func validateDocument(doc *document) []violation {
+	uri := metaschemaFor(doc.draft)
+	if uri == "" || doc.dialect() == dialectOpenAPI30 {
+		return nil
+	}
+	v, err := loadMetaschemas()
+	if err != nil {
+		return nil
+	}
+	var instance any
+	if err := decodeJSON(doc.data, &instance); err != nil {
+		return nil
+	}
+
+	roots := []string{""}
+	if doc.openAPI != "" {
+		roots = roots[:0]
+		for _, ds := range doc.schemas {
+			roots = append(roots, ds.pointer)
+		}
+	}
+	var violations []violation
+	for _, pointer := range roots {
+		value, ok := pointerValue(instance, pointer)
+		if !ok {
+			continue
+		}
+		e := &metaEvaluation{validator: v}
+		violations = append(violations, e.validate(v.resources[uri], uri, value, pointer)...)
+	}
+
+	// The 2020-12 vocabularies check some keywords twice
+	seen := make(map[string]bool)
+	unique := violations[:0]
+	for _, violation := range violations {
+		key := violation.pointer + "\x00" + violation.message
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, violation)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool { return unique[i].pointer < unique[j].pointer })
+	return unique
+}
+
+// metaschemaIssues returns the metaschema violations of d as issues.
+func (d *document) metaschemaIssues() []Issue {
+	info, _ := lookupIssueCodeInfo(CodeInvalidSchema)
+	issues := make([]Issue, len(d.violations))
+	for i, v := range d.violations {
+		issues[i] = Issue{
+			Code:     CodeInvalidSchema,
+			Severity: info.severity,
+			File:     d.file,
+			Path:     "$" + v.pointer,
+			Message:  v.message,
+		}
+	}
+	return issues
+}
+
+// withoutCoveredViolations drops invalid-schema issues reported at the same
+// place as a draft-keyword issue, which explains the problem and may fix it.
+func withoutCoveredViolations(issues []Issue) []Issue {
+	covered := make(map[[2]string]bool)
+	for _, issue := range issues {
+		if issue.Code == CodeDraftKeyword {
+			covered[[2]string{issue.File, issue.Path}] = true
+		}
+	}
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.Code == CodeInvalidSchema && covered[[2]string{issue.File, issue.Path}] {
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// decodeJSON decodes data keeping numbers exact.
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// pointerValue returns the value at pointer within a decoded JSON document.
+func pointerValue(value any, pointer string) (any, bool) {
+	if pointer == "" {
+		return value, true
+	}
+	for _, token := range SplitPointer(pointer) {
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[token]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// metaValidator validates schemas against the embedded metaschemas. It
+// implements the keywords the metaschemas use, including $dynamicRef.
+type metaValidator struct {
+	resources map[string]map[string]any // $id -> metaschema
+
+	mu       sync.Mutex
+	compiled map[string]*regexp.Regexp
+}
+
+// pattern returns the compiled regular expression for a pattern keyword.
+func (v *metaValidator) pattern(expr string) (*regexp.Regexp, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if re, ok := v.compiled[expr]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err == nil {
+		v.compiled[expr] = re
+	}
+	return re, err
+}
+
+// metaEvaluation is a single validation run. scope holds the metaschema
+// resources entered so far, outermost first, for resolving $dynamicRef.
+type metaEvaluation struct {
+	validator *metaValidator
+	scope     []string
+}
+
+// validate validates instance, found at pointer, against schema, whose base
+// URI is base.
+func (e *metaEvaluation) validate(schema any, base string, instance any, pointer string) []violation {
+	if b, ok := schema.(bool); ok {
+		if b {
+			return nil
+		}
+		return []violation{{pointer: pointer, message: fmt.Sprintf("%s is not allowed", label(pointer))}}
+	}
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if len(e.scope) == 0 || e.scope[len(e.scope)-1] != base {
+		e.scope = append(e.scope, base)
+		defer func() { e.scope = e.scope[:len(e.scope)-1] }()
+	}
+
+	// A type mismatch makes the other assertions meaningless
+	if types := typeList(s["type"]); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return hasType(instance, t) }) {
+		return []violation{{
+			pointer:  pointer,
+			message:  fmt.Sprintf("%s must be %s, not %s", label(pointer), describeTypes(types), jsonType(instance)),
+			types:    types,
+			mismatch: true,
+		}}
+	}
+
+	var violations []violation
+	add := func(format string, args ...any) {
+		violations = append(violations, violation{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		if target, targetBase, ok := e.resolve(base, ref); ok {
+			violations = append(violations, e.validate(target, targetBase, instance, pointer)...)
+		}
+	}
+	if ref, ok := s["$dynamicRef"].(string); ok {
+		if target, targetBase, ok := e.resolveDynamic(base, ref); ok {
+			violations = append(violations, e.validate(target, targetBase, instance, pointer)...)
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(v any) bool { return valuesEqual(v, instance) }) {
+		add("%s must be one of %s, not %s", label(pointer), describeValues(enum), compactValue(instance))
+		violations[len(violations)-1].mismatch = !slices.ContainsFunc(enum, func(v any) bool { return hasType(instance, jsonType(v)) })
+	}
+	if c, ok := s["const"]; ok && !valuesEqual(c, instance) {
+		add("%s must be %s", label(pointer), compactValue(c))
+	}
+
+	switch inst := instance.(type) {
+	case json.Number:
+		n, _ := new(big.Rat).SetString(inst.String())
+		if limit, ok := ratValue(s["minimum"]); ok && n.Cmp(limit) < 0 {
+			add("%s must be at least %s, not %s", label(pointer), s["minimum"], inst)
+		}
+		if limit, ok := ratValue(s["exclusiveMinimum"]); ok && n.Cmp(limit) <= 0 {
+			add("%s must be greater than %s, not %s", label(pointer), s["exclusiveMinimum"], inst)
+		}
+		if limit, ok := ratValue(s["maximum"]); ok && n.Cmp(limit) > 0 {
+			add("%s must be at most %s, not %s", label(pointer), s["maximum"], inst)
+		}
+
+	case string:
+		if expr, ok := s["pattern"].(string); ok {
+			if re, err := e.validator.pattern(expr); err == nil && !re.MatchString(inst) {
+				add("%s %q does not match %s", label(pointer), inst, expr)
+			}
+		}
+
+	case []any:
+		if limit, ok := intValue(s["minItems"]); ok && len(inst) < limit {
+			add("%s must have at least %d item(s)", label(pointer), limit)
+		}
+		if unique, _ := s["uniqueItems"].(bool); unique {
+			for i := range inst {
+				if slices.ContainsFunc(inst[:i], func(v any) bool { return valuesEqual(v, inst[i]) }) {
+					add("%s must not contain %s more than once", label(pointer), compactValue(inst[i]))
+					break
+				}
+			}
+		}
+		if items, ok := s["items"]; ok {
+			for i, item := range inst {
+				violations = append(violations, e.validate(items, base, item, pointer+"/"+strconv.Itoa(i))...)
+			}
+		}
+
+	case map[string]any:
+		if required, ok := s["required"].([]any); ok {
+			for _, name := range required {
+				if name, ok := name.(string); ok {
+					if _, present := inst[name]; !present {
+						add("%s is missing required property %q", label(pointer), name)
+					}
+				}
+			}
+		}
+		properties, _ := s["properties"].(map[string]any)
+		patterns, _ := s["patternProperties"].(map[string]any)
+		for _, name := range sortedKeys(inst) {
+			child := pointer + "/" + EscapePointerToken(name)
+			if names, ok := s["propertyNames"]; ok {
+				violations = append(violations, e.validate(names, base, name, child)...)
+			}
+			matched := false
+			if sub, ok := properties[name]; ok {
+				matched = true
+				violations = append(violations, e.validate(sub, base, inst[name], child)...)
+			}
+			for expr, sub := range patterns {
+				if re, err := e.validator.pattern(expr); err == nil && re.MatchString(name) {
+					matched = true
+					violations = append(violations, e.validate(sub, base, inst[name], child)...)
+				}
+			}
+			if additional, ok := s["additionalProperties"]; ok && !matched {
+				violations = append(violations, e.validate(additional, base, inst[name], child)...)
+			}
+		}
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			violations = append(violations, e.validate(sub, base, instance, pointer)...)
+		}
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		branches, ok := s[keyword].([]any)
+		if !ok {
+			continue
+		}
+		var failures [][]violation
+		for _, sub := range branches {
+			if branch := e.validate(sub, base, instance, pointer); len(branch) > 0 {
+				failures = append(failures, branch)
+			}
+		}
+		switch passed := len(branches) - len(failures); {
+		case passed == 0:
+			violations = append(violations, bestBranch(pointer, failures)...)
+		case passed > 1 && keyword == "oneOf":
+			add("%s matches more than one of its allowed forms", label(pointer))
+		}
+	}
+	if not, ok := s["not"]; ok && len(e.validate(not, base, instance, pointer)) == 0 {
+		add("%s is not allowed here", label(pointer))
+	}
+	return violations
+}
+
+// bestBranch picks the violations to report when no anyOf/oneOf branch
+// matches: those of the branch the value's type fits with the fewest
+// violations. If it fits none, a single type mismatch lists every accepted
+// type, unless a branch allows specific values, which are listed instead.
+func bestBranch(pointer string, failures [][]violation) []violation {
+	var best []violation
+	var types []string
+	for _, failure := range failures {
+		if len(failure) == 1 && failure[0].pointer == pointer && failure[0].mismatch {
+			for _, t := range failure[0].types {
+				if !slices.Contains(types, t) {
+					types = append(types, t)
+				}
+			}
+			continue
+		}
+		if best == nil || len(failure) < len(best) {
+			best = failure
+		}
+	}
+	switch {
+	case best != nil:
+		return best
+	case slices.ContainsFunc(failures, func(failure []violation) bool { return failure[0].types == nil }):
+		return failures[0]
+	}
+	mismatch := failures[0][0]
+	mismatch.types = types
+	_, actual, _ := strings.Cut(mismatch.message, ", not ")
+	mismatch.message = fmt.Sprintf("%s must be %s, not %s", label(pointer), describeTypes(types), actual)
+	return []violation{mismatch}
+}
+
+// resolve returns the schema ref points to, relative to base, and its base URI.
+func (e *metaEvaluation) resolve(base, ref string) (any, string, bool) {
+	uri, fragment, _ := strings.Cut(resolveURI(base, ref), "#")
+	resource, ok := e.validator.resources[uri]
+	if !ok {
+		return nil, "", false
+	}
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, "", false
+	}
+	if fragment == "" || !strings.HasPrefix(fragment, "/") {
+		// The metaschemas only declare anchors at their roots
+		if fragment != "" && resource["$dynamicAnchor"] != fragment && resource["$anchor"] != fragment {
+			return nil, "", false
+		}
+		return resource, uri, true
+	}
+	target, ok := pointerValue(any(resource), fragment)
+	return target, uri, ok
+}
+
+// resolveDynamic resolves a $dynamicRef: when its target declares a matching
+// $dynamicAnchor, the outermost resource in scope with that anchor is used.
+func (e *metaEvaluation) resolveDynamic(base, ref string) (any, string, bool) {
+	target, targetBase, ok := e.resolve(base, ref)
+	_, anchor, _ := strings.Cut(ref, "#")
+	resource := e.validator.resources[targetBase]
+	if !ok || anchor == "" || resource["$dynamicAnchor"] != anchor {
+		return target, targetBase, ok
+	}
+	for _, uri := range e.scope {
+		if outer := e.validator.resources[uri]; outer["$dynamicAnchor"] == anchor {
+			return outer, uri, true
+		}
+	}
+	return target, targetBase, ok
+}
+
+// typeList returns the types named by a type keyword value.
+func typeList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var types []string
+		for _, t := range v {
+			if t, ok := t.(string); ok {
+				types = append(types, t)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// hasType reports whether value is an instance of the JSON Schema type t.
+func hasType(value any, t string) bool {
+	actual := jsonType(value)
+	return actual == t || (t == "number" && actual == "integer")
+}
+
+// jsonType returns the JSON Schema type of a decoded value; numbers with no
+// fractional part are integers.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if n, ok := new(big.Rat).SetString(v.String()); ok && n.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "unknown"
+}
+
+// describeTypes lists types for a message, such as "an object or boolean".
+func describeTypes(types []string) string {
+	if len(types) == 0 {
+		return "a valid value"
+	}
+	article := "a"
+	if strings.ContainsAny(types[0][:1], "aeiou") {
+		article = "an"
+	}
+	return article + " " + strings.Join(types, " or ")
+}
+
+// describeValues lists the values of an enum for a message.
+func describeValues(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = compactValue(v)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compactValue formats a decoded value as compact JSON.
+func compactValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// valuesEqual compares decoded JSON values, treating numbers by value.
+func valuesEqual(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		m, ok1 := new(big.Rat).SetString(x.String())
+		n, ok2 := new(big.Rat).SetString(y.String())
+		return ok1 && ok2 && m.Cmp(n) == 0
+	case []any:
+		y, ok := b.([]any)
+		return ok && slices.EqualFunc(x, y, valuesEqual)
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !valuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// ratValue returns a numeric keyword value.
+func ratValue(value any) (*big.Rat, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetString(n.String())
+}
+
+// intValue returns an integer keyword value.
+func intValue(value any) (int, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(n.String())
+	return i, err == nil
+}
+
+// label names the value at pointer in a message: its keyword or property
+// name, with the index for array items ("required[0]").
+func label(pointer string) string {
+	tokens := SplitPointer(pointer)
+	switch n := len(tokens); {
+	case n == 0:
+		return "schema"
+	case n > 1:
+		if _, err := strconv.Atoi(tokens[n-1]); err == nil {
+			return fmt.Sprintf("%s[%s]", tokens[n-2], tokens[n-1])
+		}
+	}
+	return tokens[len(tokens)-1]
+}
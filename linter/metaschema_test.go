@@ -0,0 +1,178 @@
+package linter
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetaschemaViolations(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		path    string
+		message string
+	}{
+		{
+			name:    "unknown type",
+			schema:  `{"properties": {"name": {"type": "strin"}}}`,
+			path:    "$/properties/name/type",
+			message: `type must be one of "array", "boolean", "integer", "null", "number", "object", "string", not "strin"`,
+		},
+		{
+			name:    "type array item",
+			schema:  `{"type": ["string", 3]}`,
+			path:    "$/type/1",
+			message: "type[1] must be one of",
+		},
+		{
+			name:    "required string",
+			schema:  `{"type": "object", "required": "foo"}`,
+			path:    "$/required",
+			message: "required must be an array, not string",
+		},
+		{
+			name:    "property not a schema",
+			schema:  `{"properties": {"name": "string"}}`,
+			path:    "$/properties/name",
+			message: "name must be an object or boolean, not string",
+		},
+		{
+			name:    "negative length",
+			schema:  `{"type": "string", "minLength": -1}`,
+			path:    "$/minLength",
+			message: "minLength must be at least 0, not -1",
+		},
+		{
+			name:    "empty anyOf",
+			schema:  `{"anyOf": []}`,
+			path:    "$/anyOf",
+			message: "anyOf must have at least 1 item(s)",
+		},
+		{
+			name:    "draft-07 metaschema",
+			schema:  `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"A": {"required": [1]}}}`,
+			path:    "$/definitions/A/required/0",
+			message: "required[0] must be a string, not integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewWithDefaults().Lint([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("Failed to lint: %v", err)
+			}
+			var found []Issue
+			for _, issue := range result.Issues {
+				if issue.Code == CodeInvalidSchema {
+					found = append(found, issue)
+				}
+			}
+			if len(found) != 1 {
+				t.Fatalf("Expected one %s issue, got %v", CodeInvalidSchema, result.Issues)
+			}
+			if found[0].Path != tt.path || !strings.HasPrefix(found[0].Message, tt.message) {
+				t.Errorf("Expected %q at %s, got %q at %s", tt.message, tt.path, found[0].Message, found[0].Path)
+			}
+			if found[0].Line == 0 {
+				t.Errorf("Expected a source line for %s", found[0].Path)
+			}
+		})
+	}
+}
+
+func TestMetaschemaDrafts(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"draft-07 fragment id", `{"$schema": "http://json-schema.org/draft-07/schema#", "$id": "#root"}`},
+		{"draft-04 not validated", `{"$schema": "http://json-schema.org/draft-04/schema#", "maximum": 1, "exclusiveMaximum": true}`},
+		{"OpenAPI 3.0 not validated", `{"openapi": "3.0.3", "components": {"schemas": {"A": {"type": "string", "nullable": true, "exclusiveMinimum": true, "minimum": 0}}}}`},
+		{"unknown keywords", `{"x-go-type": "Foo", "discriminator": {"propertyName": "kind"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewWithDefaults().Lint([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("Failed to lint: %v", err)
+			}
+			for _, issue := range result.Issues {
+				if issue.Code == CodeInvalidSchema {
+					t.Errorf("Unexpected issue: %v", issue)
+				}
+			}
+		})
+	}
+}
+
+func TestMetaschemaOpenAPI31(t *testing.T) {
+	doc := `{
+  "openapi": "3.1.0",
+  "components": {"schemas": {"Pet": {"type": "object", "required": "name"}}}
+}`
+	result, err := NewWithDefaults().Lint([]byte(doc))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Path != "$/components/schemas/Pet/required" {
+		t.Errorf("Expected the Pet schema's required to be reported, got %v", result.Issues)
+	}
+}
+
+func TestMetaschemaCoveredByDraftKeyword(t *testing.T) {
+	// Boolean exclusiveMaximum is invalid in 2020-12; draft-keyword explains
+	// it and offers a fix, so it is not reported twice
+	result, err := NewWithDefaults().Lint([]byte(`{"type": "integer", "maximum": 5, "exclusiveMaximum": true}`))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeDraftKeyword {
+		t.Errorf("Expected only a %s issue, got %v", CodeDraftKeyword, result.Issues)
+	}
+}
+
+func TestMetaschemaCoveredByDraftKeywordInFile(t *testing.T) {
+	// Metaschema issues carry the file name, so rule issues must too before
+	// they are compared
+	path := filepath.Join(t.TempDir(), "schema.json")
+	doc := `{"$schema": "http://json-schema.org/draft-07/schema#", "definitions": {"A": {"exclusiveMaximum": true, "maximum": 5}}}`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewWithDefaults().LintFile(path)
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeDraftKeyword {
+		t.Errorf("Expected only a %s issue, got %v", CodeDraftKeyword, result.Issues)
+	}
+}
+
+func TestEmbeddedMetaschemasAreValid(t *testing.T) {
+	err := fs.WalkDir(metaschemaFiles, "metaschema", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := metaschemaFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		result, err := NewWithDefaults().Lint(data)
+		if err != nil {
+			t.Errorf("%s: failed to lint: %v", path, err)
+			return nil
+		}
+		for _, issue := range result.Issues {
+			if issue.Code == CodeInvalidSchema {
+				t.Errorf("%s: unexpected issue: %v", path, issue)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,182 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// openAPIMethods are the operations of an OpenAPI Path Item Object.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIVersion returns the version declared by an OpenAPI 3.x document,
+// or "" if data is not one.
+func openAPIVersion(data []byte) string {
+	var root struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil || !strings.HasPrefix(root.OpenAPI, "3.") {
+		return ""
+	}
+	return root.OpenAPI
+}
+
+// swaggerVersion returns the version declared by a Swagger 2.0 document, or
+// "" if data is not one.
+func swaggerVersion(data []byte) string {
+	var root struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(data, &root); err != nil || !strings.HasPrefix(root.Swagger, "2.") {
+		return ""
+	}
+	return root.Swagger
+}
+
+// dialect is the schema language a document is written in. It decides which
+// forms are idiomatic, such as how nullability is expressed.
+type dialect int
+
+const (
+	dialectJSONSchema dialect = iota
+	dialectOpenAPI30
+	dialectOpenAPI31
+	dialectSwagger2
+)
+
+// dialect returns the dialect the document is written in.
+func (d *document) dialect() dialect {
+	switch {
+	case strings.HasPrefix(d.openAPI, "3.0"):
+		return dialectOpenAPI30
+	case d.openAPI != "":
+		return dialectOpenAPI31
+	case d.swagger != "":
+		return dialectSwagger2
+	}
+	return dialectJSONSchema
+}
+
+// documentSchema is a root schema of a document: the document itself for a
+// JSON Schema, or a schema embedded in an OpenAPI document.
+type documentSchema struct {
+	pointer string
+	schema  *Schema
+}
+
+// openAPIScanner collects the schemas of an OpenAPI 3.x document: component
+// schemas and the schemas of parameters, headers, request bodies and
+// responses, wherever they appear. Reference Objects are skipped, since
+// their targets are collected where they are defined.
+type openAPIScanner struct {
+	schemas []documentSchema
+	err     error
+}
+
+// openAPISchemas returns the schemas of the OpenAPI document data in document
+// order. Schemas that fail to parse are returned with a nil schema, and the
+// first failure is returned as the error.
+func openAPISchemas(data []byte) ([]documentSchema, error) {
+	s := &openAPIScanner{}
+	root := s.object(json.RawMessage(data))
+	components := s.object(root["components"])
+	s.members("/components/schemas", components["schemas"], s.schema)
+	s.members("/components/parameters", components["parameters"], s.parameter)
+	s.members("/components/headers", components["headers"], s.parameter)
+	s.members("/components/requestBodies", components["requestBodies"], s.content)
+	s.members("/components/responses", components["responses"], s.response)
+	s.members("/components/callbacks", components["callbacks"], s.callback)
+	s.members("/components/pathItems", components["pathItems"], s.pathItem)
+	s.members("/paths", root["paths"], s.pathItem)
+	s.members("/webhooks", root["webhooks"], s.pathItem)
+	return s.schemas, s.err
+}
+
+// object decodes an OpenAPI object, returning nil for other values and
+// Reference Objects.
+func (s *openAPIScanner) object(raw json.RawMessage) map[string]json.RawMessage {
+	var object map[string]json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &object) != nil {
+		return nil
+	}
+	if _, ok := object["$ref"]; ok {
+		return nil
+	}
+	return object
+}
+
+// members calls fn for each member of the map at pointer, in source order.
+func (s *openAPIScanner) members(pointer string, raw json.RawMessage, fn func(string, json.RawMessage)) {
+	object := s.object(raw)
+	if object == nil {
+		return
+	}
+	for _, name := range objectKeys(raw) {
+		fn(pointer+"/"+EscapePointerToken(name), object[name])
+	}
+}
+
+// schema records the Schema Object at pointer.
+func (s *openAPIScanner) schema(pointer string, raw json.RawMessage) {
+	if len(raw) == 0 {
+		return
+	}
+	schema, err := ParseSchema(raw)
+	if err != nil && s.err == nil {
+		s.err = fmt.Errorf("%s: %w", pointer, err)
+	}
+	s.schemas = append(s.schemas, documentSchema{pointer: pointer, schema: schema})
+}
+
+// pathItem scans a Path Item Object.
+func (s *openAPIScanner) pathItem(pointer string, raw json.RawMessage) {
+	item := s.object(raw)
+	s.parameters(pointer+"/parameters", item["parameters"])
+	for _, method := range openAPIMethods {
+		if operation := s.object(item[method]); operation != nil {
+			prefix := pointer + "/" + method
+			s.parameters(prefix+"/parameters", operation["parameters"])
+			s.content(prefix+"/requestBody", operation["requestBody"])
+			s.members(prefix+"/responses", operation["responses"], s.response)
+			s.members(prefix+"/callbacks", operation["callbacks"], s.callback)
+		}
+	}
+}
+
+// callback scans a Callback Object, a map of expressions to Path Item Objects.
+func (s *openAPIScanner) callback(pointer string, raw json.RawMessage) {
+	s.members(pointer, raw, s.pathItem)
+}
+
+// parameters scans a list of Parameter Objects.
+func (s *openAPIScanner) parameters(pointer string, raw json.RawMessage) {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		return
+	}
+	for i, parameter := range list {
+		s.parameter(pointer+"/"+strconv.Itoa(i), parameter)
+	}
+}
+
+// parameter scans a Parameter or Header Object, which has either a schema or content.
+func (s *openAPIScanner) parameter(pointer string, raw json.RawMessage) {
+	parameter := s.object(raw)
+	s.schema(pointer+"/schema", parameter["schema"])
+	s.content(pointer, raw)
+}
+
+// response scans a Response Object.
+func (s *openAPIScanner) response(pointer string, raw json.RawMessage) {
+	response := s.object(raw)
+	s.members(pointer+"/headers", response["headers"], s.parameter)
+	s.content(pointer, raw)
+}
+
+// content scans the Media Type Objects in the content of the object at pointer.
+func (s *openAPIScanner) content(pointer string, raw json.RawMessage) {
+	s.members(pointer+"/content", s.object(raw)["content"], func(pointer string, raw json.RawMessage) {
+		s.schema(pointer+"/schema", s.object(raw)["schema"])
+	})
+}
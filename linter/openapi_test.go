@@ -0,0 +1,106 @@
+package linter
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLintOpenAPI(t *testing.T) {
+	doc := `{
+  "openapi": "3.1.0",
+  "info": {"title": "Shapes", "version": "1"},
+  "paths": {
+    "/shapes": {
+      "post": {
+        "parameters": [
+          {"name": "q", "in": "query", "schema": {"anyOf": [{"type": "string"}, {"type": "integer"}]}}
+        ],
+        "requestBody": {"$ref": "#/components/requestBodies/Shape"},
+        "responses": {
+          "200": {
+            "description": "OK",
+            "headers": {"X-Rate": {"schema": {"oneOf": [{"type": "string"}, {"type": "number"}]}}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Node": {
+        "type": "object",
+        "required": ["next"],
+        "properties": {"next": {"$ref": "#/components/schemas/Node"}}
+      }
+    },
+    "requestBodies": {
+      "Shape": {
+        "content": {
+          "application/json": {
+            "schema": {"oneOf": [{"type": "object"}, {"type": "array"}]}
+          }
+        }
+      }
+    }
+  }
+}`
+
+	result, err := NewWithDefaults().Lint([]byte(doc))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if result.OpenAPI != "3.1.0" {
+		t.Errorf("Expected OpenAPI version 3.1.0, got %q", result.OpenAPI)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/paths/~1shapes/post/parameters/0/schema/anyOf":                         CodeUnionNoDiscriminator,
+		"$/paths/~1shapes/post/responses/200/headers/X-Rate/schema/oneOf":         CodeUnionNoDiscriminator,
+		"$/components/requestBodies/Shape/content/application~1json/schema/oneOf": CodeUnionNoDiscriminator,
+		"$/components/schemas/Node":                                               CodeCircularReference,
+	})
+	for _, issue := range result.Issues {
+		if issue.Line == 0 {
+			t.Errorf("Expected a source line for %s", issue.Path)
+		}
+	}
+}
+
+func TestLintOpenAPIDiscriminator(t *testing.T) {
+	result, err := NewWithDefaults().LintFile(filepath.Join("..", "testdata", "openapi", "petstore.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	// Pet's discriminator object makes it a discriminated union; Cat inherits
+	// petType through allOf, Dog lacks it
+	var missing []string
+	for _, issue := range result.Issues {
+		switch {
+		case issue.Code == CodeMissingConst:
+			missing = append(missing, issue.Path)
+		case issue.Path == "$/components/schemas/Pet/oneOf":
+			t.Errorf("Unexpected issue on the discriminated union: %v", issue)
+		}
+	}
+	if len(missing) != 1 || missing[0] != "$/components/schemas/Dog" {
+		t.Errorf("Expected only Dog to lack the discriminator property, got %v", missing)
+	}
+	if result.ErrorCount() != 3 {
+		t.Errorf("Expected 3 errors, got %d: %v", result.ErrorCount(), result.Issues)
+	}
+}
+
+func TestOpenAPIVersion(t *testing.T) {
+	tests := map[string]string{
+		`{"openapi": "3.0.3", "paths": {}}`: "3.0.3",
+		`{"swagger": "2.0"}`:                "",
+		`{"type": "object"}`:                "",
+		`{"openapi": 3}`:                    "",
+	}
+	for doc, want := range tests {
+		if got := openAPIVersion([]byte(doc)); got != want {
+			t.Errorf("openAPIVersion(%s) = %q, want %q", doc, got, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package linter
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a location in a source document. Line and Column are 1-based;
+// Column counts characters, not bytes.
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// span is the source extent of a JSON value. Key is the position of the
+// member name when the value is an object member.
+type span struct {
+	Key    Position
+	HasKey bool
+	Start  Position
+	End    Position
+}
+
+// sourceMap records the source extent of every value in a document, keyed by JSON Pointer.
+type sourceMap struct {
+	spans map[string]span
+}
+
+// locate returns the span for pointer, falling back to the nearest enclosing value.
+func (m *sourceMap) locate(pointer string) (span, bool) {
+	for {
+		if s, ok := m.spans[pointer]; ok {
+			return s, true
+		}
+		if pointer == "" {
+			return span{}, false
+		}
+		pointer = pointer[:strings.LastIndex(pointer, "/")]
+	}
+}
+
+// buildSourceMap scans JSON data and records byte offsets for every value.
+// Scanning stops silently at malformed input; data is expected to have been
+// validated by the JSON decoder already.
+func buildSourceMap(data []byte) *sourceMap {
+	s := &jsonScanner{data: data, spans: make(map[string]span)}
+	s.value("")
+	s.resolvePositions()
+	return &sourceMap{spans: s.spans}
+}
+
+// jsonScanner is a minimal recursive descent JSON scanner that tracks offsets.
+type jsonScanner struct {
+	data  []byte
+	pos   int
+	spans map[string]span
+	err   bool
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonScanner) value(pointer string) {
+	s.skipSpace()
+	if s.pos >= len(s.data) {
+		s.err = true
+		return
+	}
+	sp := s.spans[pointer]
+	sp.Start = Position{Offset: s.pos}
+
+	switch s.data[s.pos] {
+	case '{':
+		s.object(pointer)
+	case '[':
+		s.array(pointer)
+	case '"':
+		s.str()
+	default:
+		for s.pos < len(s.data) && !strings.ContainsRune(",]} \t\r\n", rune(s.data[s.pos])) {
+			s.pos++
+		}
+	}
+
+	sp.End = Position{Offset: s.pos}
+	s.spans[pointer] = sp
+}
+
+func (s *jsonScanner) object(pointer string) {
+	s.pos++ // {
+	for !s.err {
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			s.err = true
+			return
+		}
+		switch s.data[s.pos] {
+		case '}':
+			s.pos++
+			return
+		case ',':
+			s.pos++
+			continue
+		case '"':
+		default:
+			s.err = true
+			return
+		}
+
+		keyStart := s.pos
+		raw := s.str()
+		var key string
+		if err := json.Unmarshal(raw, &key); err != nil {
+			s.err = true
+			return
+		}
+		s.skipSpace()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			s.err = true
+			return
+		}
+		s.pos++
+
+		child := pointer + "/" + EscapePointerToken(key)
+		s.spans[child] = span{Key: Position{Offset: keyStart}, HasKey: true}
+		s.value(child)
+	}
+}
+
+func (s *jsonScanner) array(pointer string) {
+	s.pos++ // [
+	index := 0
+	for !s.err {
+		s.skipSpace()
+		if s.pos >= len(s.data) {
+			s.err = true
+			return
+		}
+		switch s.data[s.pos] {
+		case ']':
+			s.pos++
+			return
+		case ',':
+			s.pos++
+			continue
+		}
+		s.value(pointer + "/" + strconv.Itoa(index))
+		index++
+	}
+}
+
+// str consumes a string literal and returns its raw bytes including quotes.
+func (s *jsonScanner) str() []byte {
+	start := s.pos
+	s.pos++ // opening quote
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '"':
+			s.pos++
+			return s.data[start:s.pos]
+		}
+		s.pos++
+	}
+	s.err = true
+	return s.data[start:]
+}
+
+// resolvePositions fills in line and column numbers for every recorded offset.
+func (s *jsonScanner) resolvePositions() {
+	lineStarts := []int{0}
+	for i, b := range s.data {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	resolve := func(p *Position) {
+		line := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > p.Offset }) - 1
+		p.Line = line + 1
+		p.Column = utf8.RuneCount(s.data[lineStarts[line]:p.Offset]) + 1
+	}
+	for pointer, sp := range s.spans {
+		resolve(&sp.Start)
+		resolve(&sp.End)
+		if sp.HasKey {
+			resolve(&sp.Key)
+		}
+		s.spans[pointer] = sp
+	}
+}
+
+// issuePointer converts an issue path ("$/$defs/Foo") into a JSON Pointer.
+func issuePointer(path string) string {
+	return strings.TrimPrefix(path, "$")
+}
+
+// locateIssue sets the source position of issue from m. Issues about an object
+// member point at its key, so annotations land on the offending keyword.
+func locateIssue(issue *Issue, m *sourceMap) {
+	sp, ok := m.locate(issuePointer(issue.Path))
+	if !ok {
+		return
+	}
+	start := sp.Start
+	if sp.HasKey {
+		start = sp.Key
+	}
+	issue.Line = start.Line
+	issue.Column = start.Column
+	issue.EndLine = sp.End.Line
+}
@@ -0,0 +1,80 @@
+package linter
+
+import (
+	"testing"
+)
+
+func TestIssuePositions(t *testing.T) {
+	schema := `{
+  "$defs": {
+    "Response": {
+      "anyOf": [
+        {"type": "object", "properties": {"data": {"type": "string"}}},
+        {"type": "object", "properties": {"error": {"type": "string"}}}
+      ]
+    },
+    "Näme": {
+      "properties": {"bad_name": {"type": "string"}}
+    }
+  }
+}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	positions := map[IssueCode][3]int{
+		CodeUnionNoDiscriminator: {4, 7, 7},
+		CodeInvalidPropertyCase:  {10, 22, 10},
+	}
+	for _, issue := range result.Issues {
+		want, ok := positions[issue.Code]
+		if !ok {
+			continue
+		}
+		got := [3]int{issue.Line, issue.Column, issue.EndLine}
+		if got != want {
+			t.Errorf("%s: expected line/column/end line %v, got %v", issue.Code, want, got)
+		}
+		delete(positions, issue.Code)
+	}
+	if len(positions) > 0 {
+		t.Errorf("Missing issues %v in %v", positions, result.Issues)
+	}
+}
+
+func TestSourceMapEscapedKeys(t *testing.T) {
+	data := []byte("{\n  \"a/b\": {\"c~d\": [1, {\"e\\\"f\": true}]}\n}")
+	m := buildSourceMap(data)
+
+	sp, ok := m.locate("/a~1b/c~0d/1/e\"f")
+	if !ok {
+		t.Fatal("Expected escaped pointer to be located")
+	}
+	if !sp.HasKey || sp.Key.Line != 2 || sp.Key.Column != 23 {
+		t.Errorf("Unexpected key position %+v", sp.Key)
+	}
+
+	// Unknown pointers fall back to the nearest enclosing value
+	sp, ok = m.locate("/a~1b/missing")
+	if !ok || sp.Key.Column != 3 {
+		t.Errorf("Expected fallback to /a~1b, got %+v", sp)
+	}
+}
+
+func TestGitHubAnnotationsIncludeLine(t *testing.T) {
+	result := Result{
+		SchemaPath: "schema.json",
+		Issues: []Issue{
+			{Code: CodeLargeUnion, Severity: SeverityWarning, Path: "$/oneOf", Message: "big", Line: 3, Column: 5, EndLine: 9},
+		},
+	}
+
+	got := result.GitHubAnnotations()
+	want := "::warning file=schema.json,line=3,col=5,endLine=9::large-union - big\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,190 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// protoWrappers are the google.protobuf wrapper types for nullable scalars.
+var protoWrappers = map[string]string{
+	"string":  "google.protobuf.StringValue",
+	"integer": "google.protobuf.Int64Value",
+	"int32":   "google.protobuf.Int32Value",
+	"uint32":  "google.protobuf.UInt32Value",
+	"uint64":  "google.protobuf.UInt64Value",
+	"number":  "google.protobuf.DoubleValue",
+	"float":   "google.protobuf.FloatValue",
+	"boolean": "google.protobuf.BoolValue",
+}
+
+// protoZeroNames are the words that mark an enum value as the unspecified
+// zero value proto3 requires first, as in SHAPE_UNSPECIFIED. They must be
+// whole words of the value, so NONEXISTENT does not count.
+var protoZeroNames = []string{"unspecified", "unknown", "unset", "none", "default"}
+
+// deref returns the target of a $ref schema, or schema itself if it has no
+// $ref or the reference cannot be resolved.
+func deref(ctx *RuleContext, schema *Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	target, err := ctx.Resolve(schema)
+	if err != nil {
+		return schema
+	}
+	return target.Schema
+}
+
+// isMap reports whether schema is a map: an object whose entries are only
+// described by additionalProperties or patternProperties.
+func isMap(schema *Schema) bool {
+	return schema != nil && len(schema.Properties) == 0 &&
+		(schema.AdditionalPropertiesSchema != nil || len(schema.PatternProperties) > 0)
+}
+
+// checkProtoOneof reports union variants that cannot be oneof fields: proto3
+// does not allow repeated or map fields in a oneof.
+func checkProtoOneof(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		for _, variant := range u.resolved {
+			var kind string
+			switch {
+			case variant.schema == nil:
+				continue
+			case variant.schema.IsArray() && !variant.schema.IsObject():
+				kind = "an array, which would be a repeated field"
+			case isMap(variant.schema):
+				kind = "a map"
+			default:
+				continue
+			}
+			ctx.Report(Issue{
+				File:       variant.file,
+				Path:       variant.path,
+				Message:    fmt.Sprintf("%s variant is %s, which a proto3 oneof cannot contain", u.keyword, kind),
+				Suggestion: "Wrap the variant in a message with the repeated or map field",
+			})
+		}
+	}
+}
+
+// checkProtoMap reports maps proto3 cannot express. A proto3 map has one
+// string or integer key type and one value type, which is neither repeated
+// nor a map, and a message has no catch-all for undeclared fields.
+func checkProtoMap(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	if len(schema.PatternProperties) > 0 {
+		ctx.Report(Issue{
+			Path:       node.Path + "/patternProperties",
+			Message:    "patternProperties gives keys matching different patterns different values, which a proto3 map cannot express",
+			Suggestion: "Use additionalProperties with a single value schema, or a field per pattern",
+		})
+	}
+	if names := schema.PropertyNames; names != nil && (names.Const != nil || len(names.Enum) > 0) && schema.AdditionalPropertiesSchema != nil {
+		ctx.Report(Issue{
+			Path:       node.Path + "/propertyNames",
+			Message:    "propertyNames limits the map's keys to an enum, and proto3 map keys cannot be enums",
+			Suggestion: "Use string keys, or a message with a field per key",
+		})
+	}
+	value := schema.AdditionalPropertiesSchema
+	if value == nil {
+		return
+	}
+	if len(schema.Properties) > 0 {
+		ctx.Report(Issue{
+			Path:       node.Path + "/additionalProperties",
+			Message:    "additionalProperties next to properties has no proto3 equivalent; a message cannot hold undeclared fields",
+			Suggestion: "Move the additional entries into a separate map-valued property",
+		})
+	}
+	if value = deref(ctx, value); value.IsArray() || isMap(value) {
+		ctx.Report(Issue{
+			Path:       node.Path + "/additionalProperties",
+			Message:    "map values are arrays or maps, which proto3 map values cannot be",
+			Suggestion: "Wrap the map value in a message",
+		})
+	}
+}
+
+// checkProtoNestedArray reports arrays of arrays, which would be repeated
+// repeated fields.
+func checkProtoNestedArray(ctx *RuleContext, node *Node) {
+	if !node.Schema.IsArray() || node.Schema.Items == nil {
+		return
+	}
+	if items := deref(ctx, node.Schema.Items); items.IsArray() {
+		ctx.Report(Issue{
+			Path:       node.Path + "/items",
+			Message:    "array of arrays would be a repeated repeated field, which proto3 does not allow",
+			Suggestion: "Wrap the inner array in a message with a repeated field",
+		})
+	}
+}
+
+// checkProtoNullableScalar reports nullable scalars. proto3 scalars cannot
+// tell null from the zero value, so they need a google.protobuf wrapper type.
+func checkProtoNullableScalar(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	if !schema.IsNullable() {
+		return
+	}
+	scalar := schema
+	if variants := schema.GetUnionVariants(); isNullablePattern(variants) {
+		for _, v := range variants {
+			if v != nil && v.Type != "null" {
+				scalar = deref(ctx, v)
+			}
+		}
+	}
+	types := slices.DeleteFunc(slices.Clone(scalar.types()), func(t string) bool { return t == "null" })
+	if len(types) != 1 {
+		return
+	}
+	wrapper, ok := protoWrappers[scalar.Format]
+	if !ok || (types[0] != "integer" && types[0] != "number") {
+		wrapper, ok = protoWrappers[types[0]]
+	}
+	if !ok || len(scalar.Enum) > 0 {
+		return
+	}
+	ctx.Report(Issue{
+		Path:       node.Path,
+		Message:    fmt.Sprintf("nullable %s cannot be a proto3 scalar, which does not tell null from the zero value", types[0]),
+		Suggestion: fmt.Sprintf("Map it to %s", wrapper),
+	})
+}
+
+// checkProtoEnumZero reports enums whose first value cannot be the proto3
+// zero value, which is what an unset field reads as: integer enums whose
+// first value is not 0, and string enums whose first value is a real value
+// rather than an unspecified one such as SHAPE_UNSPECIFIED. Map keys
+// limited by propertyNames are reported by checkProtoMap instead.
+func checkProtoEnumZero(ctx *RuleContext, node *Node) {
+	if node.Keyword == "propertyNames" {
+		return
+	}
+	values := slices.DeleteFunc(slices.Clone(node.Schema.Enum), func(v any) bool { return v == nil })
+	if len(values) == 0 {
+		return
+	}
+	issue := Issue{Path: node.Path + "/enum"}
+	switch first := values[0].(type) {
+	case float64:
+		if first == 0 {
+			return
+		}
+		issue.Message = fmt.Sprintf("first enum value %v is not 0, which proto3 requires as the first enum value", first)
+		issue.Suggestion = "Add 0 as the first value, meaning unspecified"
+	case string:
+		if slices.ContainsFunc(splitWords(first), func(word string) bool { return slices.Contains(protoZeroNames, strings.ToLower(word)) }) {
+			return
+		}
+		issue.Message = fmt.Sprintf("first enum value '%s' becomes the proto3 zero value, which unset fields also read as", first)
+		issue.Suggestion = "Add an unspecified value (e.g. 'UNSPECIFIED') as the first value"
+	default:
+		return
+	}
+	ctx.Report(issue)
+}
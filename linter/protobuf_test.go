@@ -0,0 +1,96 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProtobufProfile(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Tags": {"type": "array", "items": {"type": "string"}},
+			"Value": {
+				"oneOf": [
+					{"type": "object", "properties": {"kind": {"const": "text"}, "text": {"type": "string"}}},
+					{"type": "object", "properties": {"kind": {"const": "list"}, "items": {"$ref": "#/$defs/Tags"}}},
+					{"$ref": "#/$defs/Tags"},
+					{"type": "object", "additionalProperties": {"type": "string"}}
+				]
+			},
+			"Shape": {"type": "string", "enum": ["circle", "square"]},
+			"Kind": {"type": "string", "enum": ["KIND_UNSPECIFIED", "KIND_A"]},
+			"Status": {"type": "string", "enum": ["NONEXISTENT", "ACTIVE"]},
+			"Mode": {"type": "string", "enum": ["mode-unknown", "fast"]},
+			"Shade": {"type": "string", "enum": ["shadeUnspecified", "dark"]},
+			"Stage": {"type": "string", "enum": ["nonexistentStage", "draft"]},
+			"Level": {"type": "integer", "enum": [1, 2, 0]},
+			"Record": {
+				"type": "object",
+				"properties": {
+					"name": {"type": ["string", "null"]},
+					"count": {"type": ["integer", "null"], "format": "int32"},
+					"score": {"anyOf": [{"type": "number"}, {"type": "null"}]},
+					"parent": {"type": ["object", "null"]},
+					"matrix": {"type": "array", "items": {"type": "array", "items": {"type": "number"}}},
+					"groups": {"type": "object", "additionalProperties": {"$ref": "#/$defs/Tags"}},
+					"byColor": {"type": "object", "propertyNames": {"enum": ["red", "blue"]}, "additionalProperties": {"type": "integer"}},
+					"env": {"type": "object", "patternProperties": {"^X-": {"type": "string"}}},
+					"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+				},
+				"additionalProperties": {"type": "string"}
+			}
+		}
+	}`
+	config := DefaultConfig()
+	config.Profile = ProfileProtobuf
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	var proto Result
+	for _, issue := range result.Issues {
+		if strings.HasPrefix(string(issue.Code), "proto-") {
+			proto.Issues = append(proto.Issues, issue)
+		}
+	}
+	assertIssues(t, &proto, map[string]IssueCode{
+		"$/$defs/Tags":                                          CodeProtoOneof,
+		"$/$defs/Value/oneOf/3":                                 CodeProtoOneof,
+		"$/$defs/Shape/enum":                                    CodeProtoEnumZero,
+		"$/$defs/Status/enum":                                   CodeProtoEnumZero,
+		"$/$defs/Stage/enum":                                    CodeProtoEnumZero,
+		"$/$defs/Level/enum":                                    CodeProtoEnumZero,
+		"$/$defs/Record/properties/name":                        CodeProtoNullableScalar,
+		"$/$defs/Record/properties/count":                       CodeProtoNullableScalar,
+		"$/$defs/Record/properties/score":                       CodeProtoNullableScalar,
+		"$/$defs/Record/properties/matrix/items":                CodeProtoNestedArray,
+		"$/$defs/Record/properties/groups/additionalProperties": CodeProtoMap,
+		"$/$defs/Record/properties/byColor/propertyNames":       CodeProtoMap,
+		"$/$defs/Record/properties/env/patternProperties":       CodeProtoMap,
+		"$/$defs/Record/additionalProperties":                   CodeProtoMap,
+	})
+
+	// Message or suggestion text expected for each issue
+	texts := map[string]string{
+		"$/$defs/Tags":                                          "array",
+		"$/$defs/Value/oneOf/3":                                 "map",
+		"$/$defs/Shape/enum":                                    "'circle'",
+		"$/$defs/Status/enum":                                   "'NONEXISTENT'",
+		"$/$defs/Stage/enum":                                    "'nonexistentStage'",
+		"$/$defs/Level/enum":                                    "first enum value 1",
+		"$/$defs/Record/properties/name":                        "google.protobuf.StringValue",
+		"$/$defs/Record/properties/count":                       "google.protobuf.Int32Value",
+		"$/$defs/Record/properties/score":                       "google.protobuf.DoubleValue",
+		"$/$defs/Record/properties/matrix/items":                "repeated repeated",
+		"$/$defs/Record/properties/groups/additionalProperties": "arrays or maps",
+		"$/$defs/Record/properties/byColor/propertyNames":       "enum",
+		"$/$defs/Record/properties/env/patternProperties":       "patternProperties",
+		"$/$defs/Record/additionalProperties":                   "next to properties",
+	}
+	for _, issue := range proto.Issues {
+		if !strings.Contains(issue.Message+" "+issue.Suggestion, texts[issue.Path]) {
+			t.Errorf("Expected %q in %v", texts[issue.Path], issue)
+		}
+	}
+}
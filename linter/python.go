@@ -0,0 +1,76 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// pythonKeywords are the Python keywords, which no attribute may be named.
+var pythonKeywords = []string{
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break",
+	"class", "continue", "def", "del", "elif", "else", "except", "finally",
+	"for", "from", "global", "if", "import", "in", "is", "lambda", "nonlocal",
+	"not", "or", "pass", "raise", "return", "try", "while", "with", "yield",
+}
+
+// pydanticAttributes are the BaseModel attributes a field must not shadow:
+// the methods Pydantic v2 keeps from v1, along with the model_ namespace
+// (see checkPythonPropertyName).
+var pydanticAttributes = []string{
+	"construct", "copy", "dict", "fields", "from_orm", "json", "parse_file",
+	"parse_obj", "parse_raw", "schema", "schema_json", "update_forward_refs", "validate",
+}
+
+// checkPythonDiscriminator reports union variants whose discriminator is not
+// required. Pydantic builds a discriminated union from Literal fields, and
+// datamodel-codegen gives a field outside required a default of None, which
+// Pydantic rejects as a discriminator.
+func checkPythonDiscriminator(ctx *RuleContext, node *Node) {
+	reportOptionalDiscriminators(ctx, node, "it becomes Optional and Pydantic cannot use it as the Literal discriminator")
+}
+
+// checkPythonPropertyName reports property names that are Python keywords,
+// which generated models must rename behind an alias, and names that shadow
+// BaseModel attributes or fall in Pydantic's protected model_ namespace.
+func checkPythonPropertyName(ctx *RuleContext, node *Node) {
+	for name := range node.Schema.Properties {
+		issue := Issue{Path: fmt.Sprintf("%s/properties/%s", node.Path, EscapePointerToken(name))}
+		switch {
+		case slices.Contains(pythonKeywords, name):
+			issue.Message = fmt.Sprintf("Property '%s' is a Python keyword, so the generated field is renamed (%s_) with an alias", name, name)
+			issue.Suggestion = "Rename the property"
+		case slices.Contains(pydanticAttributes, name):
+			issue.Message = fmt.Sprintf("Property '%s' shadows the BaseModel attribute %s", name, name)
+			issue.Suggestion = "Rename the property, or generate the field under another name with an alias"
+		case strings.HasPrefix(name, "model_"):
+			issue.Message = fmt.Sprintf("Property '%s' is in Pydantic's protected model_ namespace", name)
+			issue.Suggestion = "Rename the property, or generate the field under another name with an alias"
+		default:
+			continue
+		}
+		ctx.Report(issue)
+	}
+}
+
+// checkPythonMixedEnum reports enums whose values have different JSON types
+// (null aside), which datamodel-codegen cannot turn into one Enum class.
+func checkPythonMixedEnum(ctx *RuleContext, node *Node) {
+	var types []string
+	for _, value := range node.Schema.Enum {
+		t := jsonType(value)
+		if t == "integer" {
+			t = "number"
+		}
+		if t != "null" && !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if len(types) > 1 {
+		ctx.Report(Issue{
+			Path:       node.Path + "/enum",
+			Message:    fmt.Sprintf("enum mixes value types (%s), which a Python Enum cannot model", strings.Join(types, ", ")),
+			Suggestion: "Use values of a single type, or a union of separate enums",
+		})
+	}
+}
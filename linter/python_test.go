@@ -0,0 +1,46 @@
+package linter
+
+import (
+	"testing"
+)
+
+func TestPythonProfile(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Shape": {
+				"oneOf": [
+					{"type": "object", "required": ["kind"], "properties": {"kind": {"const": "circle"}, "radius": {"type": "number"}}},
+					{"type": "object", "properties": {"kind": {"const": "square"}, "side": {"type": "number"}}}
+				]
+			},
+			"Record": {
+				"type": "object",
+				"properties": {
+					"class": {"type": "string"},
+					"json": {"type": "string"},
+					"copy": {"type": "boolean"},
+					"model_name": {"type": "string"},
+					"type": {"type": "string"},
+					"status": {"enum": ["active", 1, null]},
+					"level": {"enum": [1, 2.5, null]}
+				}
+			}
+		}
+	}`
+	config := DefaultConfig()
+	config.PropertyCase = CaseNone
+	config.Profile = ProfilePython
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/$defs/Shape/oneOf/1/properties/kind": CodePythonDiscriminator,
+		"$/$defs/Record/properties/class":       CodePythonPropertyName,
+		"$/$defs/Record/properties/json":        CodePythonPropertyName,
+		"$/$defs/Record/properties/copy":        CodePythonPropertyName,
+		"$/$defs/Record/properties/model_name":  CodePythonPropertyName,
+		"$/$defs/Record/properties/status/enum": CodePythonMixedEnum,
+	})
+}
@@ -0,0 +1,381 @@
+package linter
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// maxRefChain bounds how many $ref hops are followed before giving up.
+const maxRefChain = 32
+
+// ResolvedRef is the target of a $ref.
+type ResolvedRef struct {
+	// Schema is the target schema.
+	Schema *Schema
+	// URI is the absolute URI of the document containing the target.
+	URI string
+	// File is the file the target was loaded from, for reporting.
+	File string
+	// Pointer is the JSON Pointer of the target within its document.
+	Pointer string
+}
+
+// Resolver dereferences $ref pointers against a set of schema documents.
+// Local pointers (e.g. "#/$defs/Foo") are resolved within the referring
+// document; other references are resolved against the base URI in effect
+// ($id, or the retrieval URI) and loaded on demand through a Loader.
+type Resolver struct {
+	loader    Loader
+	docs      map[string]*document // retrieval URI -> document
+	resources map[string]resource  // absolute $id or retrieval URI -> schema resource
+	baseOf    map[*Schema]string   // schema -> base URI in effect
+	docOf     map[*Schema]*document
+	aliases   []uriAlias
+	draft     Draft // draft of every document, unless DraftAuto or empty
+}
+
+// document is a parsed schema file.
+type document struct {
+	uri     string
+	file    string
+	data    []byte
+	root    *Schema          // nil for OpenAPI documents
+	schemas []documentSchema // root schemas: root, or the schemas of an OpenAPI document
+	openAPI string           // OpenAPI version, if the document is an OpenAPI document
+	swagger string           // Swagger version, if the document is a Swagger 2.0 document
+	draft   Draft
+	// violations are the places the document does not conform to its
+	// draft's metaschema; parseErr is set if it could not be parsed.
+	violations []violation
+	parseErr   error
+	nodes      map[string]*Schema // JSON Pointer -> schema
+	sources    *sourceMap
+}
+
+// sourceMap returns the document's source positions, building them on first use.
+func (d *document) sourceMap() *sourceMap {
+	if d.sources == nil {
+		d.sources = buildSourceMap(d.data)
+	}
+	return d.sources
+}
+
+// resource is a schema identified by a URI: a document root or a subschema with $id.
+type resource struct {
+	doc     *document
+	pointer string
+}
+
+// uriAlias maps the directory of a document's $id onto the directory it was
+// retrieved from, so sibling references under the $id can be loaded locally.
+type uriAlias struct {
+	idPrefix        string
+	retrievalPrefix string
+}
+
+// NewResolver creates a Resolver that loads external documents through loader.
+// A nil loader limits resolution to documents added with AddDocument.
+func NewResolver(loader Loader) *Resolver {
+	return &Resolver{
+		loader:    loader,
+		docs:      make(map[string]*document),
+		resources: make(map[string]resource),
+		baseOf:    make(map[*Schema]string),
+		docOf:     make(map[*Schema]*document),
+	}
+}
+
+// AddDocument parses data, retrieved from uri, and registers it with the
+// resolver. file is the name used when reporting issues found in the document.
+// An OpenAPI 3.x document is registered with the schemas it contains, and
+// since it is not itself a schema, AddDocument returns nil for it.
+func (r *Resolver) AddDocument(uri, file string, data []byte) (*Schema, error) {
+	doc, err := r.addDocument(uri, file, data, FormatAuto)
+	if err != nil {
+		return nil, err
+	}
+	if doc.parseErr != nil {
+		return nil, doc.parseErr
+	}
+	return doc.root, nil
+}
+
+// addDocument registers data, written in format (FormatAuto to detect it),
+// after validating it against its draft's metaschema. A schema that does not
+// parse but has metaschema violations explaining why is still registered,
+// with no schemas and parseErr set, so the violations can be reported.
+func (r *Resolver) addDocument(uri, file string, data []byte, format InputFormat) (*document, error) {
+	if format == "" || format == FormatAuto {
+		format = DetectInputFormat(uri, data)
+	}
+	converted, sources, err := decodeInput(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	uri = stripFragment(uri)
+	doc := &document{
+		uri:     uri,
+		file:    file,
+		data:    converted,
+		nodes:   make(map[string]*Schema),
+		sources: sources,
+	}
+
+	doc.openAPI = openAPIVersion(doc.data)
+	doc.swagger = swaggerVersion(doc.data)
+	doc.draft = documentDraft(doc, r.draft)
+	if doc.openAPI != "" {
+		doc.schemas, err = openAPISchemas(doc.data)
+		doc.violations = validateDocument(doc)
+		// Keep the schemas that parsed
+		doc.schemas = slices.DeleteFunc(doc.schemas, func(ds documentSchema) bool { return ds.schema == nil })
+	} else {
+		doc.violations = validateDocument(doc)
+		if doc.root, err = ParseSchema(doc.data); err == nil {
+			doc.schemas = []documentSchema{{schema: doc.root}}
+		}
+	}
+	if err != nil {
+		if len(doc.violations) == 0 {
+			return nil, err
+		}
+		doc.parseErr = err
+	}
+	r.docs[uri] = doc
+	r.resources[uri] = resource{doc: doc}
+
+	base := uri
+	if root := doc.root; root != nil && root.id(doc.draft) != "" {
+		base = resolveURI(uri, stripFragment(root.id(doc.draft)))
+		if dir, retrievalDir := uriDir(base), uriDir(uri); dir != retrievalDir {
+			r.aliases = append(r.aliases, uriAlias{idPrefix: dir, retrievalPrefix: retrievalDir})
+		}
+	}
+	for _, ds := range doc.schemas {
+		r.index(doc, ds, base)
+	}
+	return doc, nil
+}
+
+// document returns the registered document reported under file.
+func (r *Resolver) document(file string) (*document, bool) {
+	for _, doc := range r.docs {
+		if doc.file == file {
+			return doc, true
+		}
+	}
+	return nil, false
+}
+
+// index records every addressable subschema of doc under its JSON Pointer,
+// along with the base URI in effect for it.
+func (r *Resolver) index(doc *document, root documentSchema, base string) {
+	_ = Walk(root.schema, func(v Visit) error {
+		schema := v.Schema
+		if v.Parent != nil {
+			base = r.baseOf[v.Parent]
+		}
+		doc.nodes[v.Pointer] = schema
+
+		id := schema.id(doc.draft)
+		anchors := []string{schema.Anchor, schema.DynamicAnchor}
+		switch {
+		case strings.HasPrefix(id, "#"):
+			// Before 2019-09, a plain-name fragment id is an anchor
+			if doc.draft.before(Draft2019) {
+				anchors = append(anchors, id[1:])
+			}
+		case id != "":
+			base = resolveURI(base, stripFragment(id))
+			r.resources[base] = resource{doc: doc, pointer: v.Pointer}
+		}
+		for _, anchor := range anchors {
+			if anchor != "" {
+				r.resources[stripFragment(base)+"#"+anchor] = resource{doc: doc, pointer: v.Pointer}
+			}
+		}
+		r.baseOf[schema] = base
+		r.docOf[schema] = doc
+		return nil
+	}, walkAt(root.pointer))
+}
+
+// Resolve follows ref, which appears in schema from, along with any $ref chain
+// at its target, and returns the final target.
+func (r *Resolver) Resolve(from *Schema, ref string) (*ResolvedRef, error) {
+	seen := make(map[string]bool)
+	for range maxRefChain {
+		target, err := r.lookup(from, ref)
+		if err != nil {
+			return nil, err
+		}
+		key := target.URI + "#" + target.Pointer
+		if seen[key] {
+			return nil, fmt.Errorf("circular $ref chain at %q", ref)
+		}
+		seen[key] = true
+
+		if target.Schema.Ref == "" {
+			return target, nil
+		}
+		from, ref = target.Schema, target.Schema.Ref
+	}
+	return nil, fmt.Errorf("$ref chain too long at %q", ref)
+}
+
+// lookup resolves a single $ref hop without following $ref chains.
+func (r *Resolver) lookup(from *Schema, ref string) (*ResolvedRef, error) {
+	resourceURI, fragment, _ := strings.Cut(ref, "#")
+
+	var res resource
+	if resourceURI == "" {
+		doc, ok := r.docOf[from]
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		// A same-document reference is relative to the enclosing resource.
+		res = r.resources[r.baseOf[from]]
+		if res.doc != doc {
+			res = resource{doc: doc}
+		}
+	} else {
+		uri := resolveURI(r.baseOf[from], resourceURI)
+		var err error
+		if res, err = r.resource(uri); err != nil {
+			return nil, fmt.Errorf("unresolvable $ref %q: %w", ref, err)
+		}
+	}
+
+	var pointer string
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		// A plain-name fragment refers to an $anchor in the target resource
+		anchored, ok := r.resources[resolveURI(r.baseOf[from], ref)]
+		if !ok || anchored.doc != res.doc {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		pointer = anchored.pointer
+	} else {
+		var err error
+		if pointer, err = fragmentPointer(fragment); err != nil {
+			return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+		}
+		pointer = res.pointer + pointer
+	}
+
+	target, ok := res.doc.nodes[pointer]
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref %q", ref)
+	}
+	return &ResolvedRef{
+		Schema:  target,
+		URI:     res.doc.uri,
+		File:    res.doc.file,
+		Pointer: pointer,
+	}, nil
+}
+
+// resource returns the schema resource identified by uri, loading it if needed.
+func (r *Resolver) resource(uri string) (resource, error) {
+	if res, ok := r.resources[uri]; ok {
+		return res, nil
+	}
+	if r.loader == nil {
+		return resource{}, fmt.Errorf("no loader for %q", uri)
+	}
+
+	candidates := []string{uri}
+	for _, alias := range r.aliases {
+		if rest, ok := strings.CutPrefix(uri, alias.idPrefix); ok {
+			candidates = append(candidates, alias.retrievalPrefix+rest)
+		}
+	}
+
+	var loadErr error
+	for _, candidate := range candidates {
+		if res, ok := r.resources[candidate]; ok {
+			return res, nil
+		}
+		data, err := r.loader.Load(candidate)
+		if err != nil {
+			loadErr = err
+			continue
+		}
+		if _, err := r.addDocument(candidate, uriFile(candidate), data, FormatAuto); err != nil {
+			return resource{}, fmt.Errorf("failed to parse %q: %w", candidate, err)
+		}
+		if candidate != uri {
+			r.resources[uri] = r.resources[candidate]
+		}
+		return r.resources[uri], nil
+	}
+	return resource{}, loadErr
+}
+
+// fragmentPointer converts a $ref fragment into a normalized JSON Pointer.
+func fragmentPointer(fragment string) (string, error) {
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return "", err
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return "", fmt.Errorf("unsupported fragment %q", fragment)
+	}
+	tokens := SplitPointer(fragment)
+	for i, token := range tokens {
+		tokens[i] = EscapePointerToken(token)
+	}
+	if len(tokens) == 0 {
+		return "", nil
+	}
+	return "/" + strings.Join(tokens, "/"), nil
+}
+
+// resolveURI resolves ref against base per RFC 3986.
+func resolveURI(base, ref string) string {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil || base == "" {
+		return refURL.String()
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+func stripFragment(uri string) string {
+	uri, _, _ = strings.Cut(uri, "#")
+	return uri
+}
+
+// uriDir returns uri up to and including its last "/".
+func uriDir(uri string) string {
+	return uri[:strings.LastIndex(uri, "/")+1]
+}
+
+// EscapePointerToken escapes a reference token per RFC 6901 ("~" -> "~0", "/" -> "~1").
+func EscapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
+
+// UnescapePointerToken reverses EscapePointerToken.
+func UnescapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~1", "/")
+	return strings.ReplaceAll(token, "~0", "~")
+}
+
+// SplitPointer splits an RFC 6901 JSON Pointer into unescaped reference tokens.
+func SplitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, part := range parts {
+		parts[i] = UnescapePointerToken(part)
+	}
+	return parts
+}
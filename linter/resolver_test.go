@@ -0,0 +1,182 @@
+package linter
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func newTestResolver(t *testing.T, uri, data string, loader Loader) (*Resolver, *Schema) {
+	t.Helper()
+	r := NewResolver(loader)
+	schema, err := r.AddDocument(uri, uri, []byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return r, schema
+}
+
+func TestResolverEscapedPointer(t *testing.T) {
+	data := `{
+		"$defs": {
+			"a/b": {"type": "string"},
+			"c~d": {"$ref": "#/$defs/a~1b"}
+		}
+	}`
+	r, root := newTestResolver(t, "", data, nil)
+
+	target, err := r.Resolve(root, "#/$defs/c~0d")
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if target.Schema.Type != "string" {
+		t.Errorf("Expected string target, got %q", target.Schema.Type)
+	}
+	if target.Pointer != "/$defs/a~1b" {
+		t.Errorf("Expected pointer /$defs/a~1b, got %q", target.Pointer)
+	}
+}
+
+func TestResolverAnchor(t *testing.T) {
+	data := `{
+		"$id": "https://example.com/main.json",
+		"$defs": {
+			"Item": {"$anchor": "item", "type": "string"}
+		},
+		"items": {"$ref": "#item"}
+	}`
+	r, root := newTestResolver(t, "https://example.com/main.json", data, nil)
+
+	target, err := r.Resolve(root.Items, root.Items.Ref)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if target.Pointer != "/$defs/Item" {
+		t.Errorf("Expected pointer /$defs/Item, got %q", target.Pointer)
+	}
+	if _, err := r.Resolve(root, "#missing"); err == nil {
+		t.Error("Expected error for unknown anchor")
+	}
+}
+
+func TestResolverDefinitions(t *testing.T) {
+	data := `{"definitions": {"Foo": {"type": "object"}}}`
+	r, root := newTestResolver(t, "", data, nil)
+
+	if _, err := r.Resolve(root, "#/definitions/Foo"); err != nil {
+		t.Errorf("Failed to resolve definitions ref: %v", err)
+	}
+	if _, err := r.Resolve(root, "#/definitions/Missing"); err == nil {
+		t.Error("Expected error for missing target")
+	}
+	if _, err := r.Resolve(root, "other.json#/definitions/Foo"); err == nil {
+		t.Error("Expected error for external ref without a loader")
+	}
+}
+
+func TestResolverExternalIDBase(t *testing.T) {
+	loader := MapLoader{
+		"https://example.com/schemas/main.json": []byte(`{
+			"$id": "https://example.com/schemas/main.json",
+			"$defs": {
+				"Nested": {
+					"$id": "nested/item.json",
+					"$defs": {"Local": {"type": "integer"}}
+				}
+			}
+		}`),
+		"https://example.com/schemas/nested/address.json": []byte(`{
+			"$defs": {"Address": {"type": "object"}}
+		}`),
+	}
+	data, _ := loader.Load("https://example.com/schemas/main.json")
+	r, root := newTestResolver(t, "https://example.com/schemas/main.json", string(data), loader)
+
+	nested := root.Defs["Nested"]
+	target, err := r.Resolve(nested, "address.json#/$defs/Address")
+	if err != nil {
+		t.Fatalf("Failed to resolve relative to nested $id: %v", err)
+	}
+	if target.URI != "https://example.com/schemas/nested/address.json" {
+		t.Errorf("Unexpected target URI %q", target.URI)
+	}
+
+	target, err = r.Resolve(nested, "#/$defs/Local")
+	if err != nil {
+		t.Fatalf("Failed to resolve within embedded resource: %v", err)
+	}
+	if target.Pointer != "/$defs/Nested/$defs/Local" {
+		t.Errorf("Unexpected pointer %q", target.Pointer)
+	}
+}
+
+func TestLintDocumentCrossFileUnion(t *testing.T) {
+	loader := MapLoader{
+		"https://example.com/main.json": []byte(`{
+			"$defs": {
+				"Pet": {
+					"oneOf": [
+						{"$ref": "pets.json#/$defs/Dog"},
+						{"$ref": "pets.json#/$defs/Cat"}
+					]
+				}
+			}
+		}`),
+		"https://example.com/pets.json": []byte(`{
+			"$defs": {
+				"Dog": {"type": "object", "properties": {"type": {"const": "dog"}}},
+				"Cat": {"type": "object", "properties": {"type": {"const": "cat"}}, "additionalProperties": true}
+			}
+		}`),
+	}
+
+	l := NewWithDefaults()
+	result, err := l.LintDocument("https://example.com/main.json", loader)
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	found := false
+	for _, issue := range result.Issues {
+		if issue.Code == CodeUnionNoDiscriminator {
+			t.Errorf("Cross-file union has a discriminator: %v", issue)
+		}
+		if issue.Code == CodeAdditionalProps {
+			found = true
+			if issue.File != "https://example.com/pets.json" || issue.Path != "$/$defs/Cat" {
+				t.Errorf("Expected issue in pets.json at $/$defs/Cat, got %s %s", issue.File, issue.Path)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected additional-properties warning from pets.json, got: %v", result.Issues)
+	}
+}
+
+func TestLintFileExternalRefs(t *testing.T) {
+	path := filepath.Join("..", "testdata", "refs", "shapes.json")
+
+	l := NewWithDefaults()
+	result, err := l.LintFile(path)
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	var additional *Issue
+	for i, issue := range result.Issues {
+		if issue.Code == CodeUnionNoDiscriminator {
+			t.Errorf("Expected kind discriminator to be found across files: %v", issue)
+		}
+		if issue.Code == CodeAdditionalProps {
+			additional = &result.Issues[i]
+		}
+	}
+	if additional == nil {
+		t.Fatalf("Expected additional-properties warning for square.json, got: %v", result.Issues)
+	}
+	if filepath.Base(additional.File) != "square.json" {
+		t.Errorf("Expected issue reported in square.json, got %q", additional.File)
+	}
+	if additional.Path != "$/$defs/Square" {
+		t.Errorf("Expected path $/$defs/Square, got %q", additional.Path)
+	}
+}
@@ -0,0 +1,170 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Rule is a lint check. The linter calls Check for every schema node it
+// visits; the rule reports problems through the RuleContext.
+//
+// Rules are registered with Register, typically from an init function, and
+// run for every Linter whose profile they apply to.
+type Rule interface {
+	// ID is the issue code the rule reports; it is also the name used to
+	// configure the rule (see Config.Rules).
+	ID() IssueCode
+	// DefaultSeverity is the severity issues are reported with unless the
+	// rule reports another severity or the configuration overrides it.
+	DefaultSeverity() Severity
+	// Description is a short, one-line description of what the rule checks.
+	Description() string
+	// Profiles lists the profiles the rule runs in; nil means all profiles.
+	Profiles() []Profile
+	// Check inspects node and reports any issues through ctx.
+	Check(ctx *RuleContext, node *Node)
+}
+
+// Node is a schema visited by the linter: its location from Walk, along with
+// linter-specific context.
+type Node struct {
+	Visit
+	// Path is the location of the schema: "$" followed by its JSON Pointer.
+	Path string
+	// UnionDepth is the number of unions (anyOf/oneOf) enclosing the schema.
+	UnionDepth int
+}
+
+// IsRoot reports whether node is a root schema of its document: the document
+// itself, or a schema embedded in an OpenAPI document.
+func (n *Node) IsRoot() bool {
+	return n.Parent == nil
+}
+
+// RuleContext gives rules access to the linter configuration and $ref
+// resolution, and collects the issues they report.
+type RuleContext struct {
+	config   Config
+	resolver *Resolver
+	result   *Result
+	rule     Rule
+	unions   map[*Schema][]*union
+}
+
+// newRuleContext creates the context shared by all rules linting one document.
+func newRuleContext(config Config, resolver *Resolver, result *Result) *RuleContext {
+	return &RuleContext{
+		config:   config,
+		resolver: resolver,
+		result:   result,
+		unions:   make(map[*Schema][]*union),
+	}
+}
+
+// Config returns the configuration of the running linter.
+func (c *RuleContext) Config() Config {
+	return c.config
+}
+
+// Resolve follows the $ref of schema to its target, which may be in another document.
+func (c *RuleContext) Resolve(schema *Schema) (*ResolvedRef, error) {
+	if schema == nil || schema.Ref == "" {
+		return nil, fmt.Errorf("schema has no $ref")
+	}
+	return c.resolver.Resolve(schema, schema.Ref)
+}
+
+// dialect returns the dialect of the document containing schema.
+func (c *RuleContext) dialect(schema *Schema) dialect {
+	if doc, ok := c.resolver.docOf[schema]; ok {
+		return doc.dialect()
+	}
+	return dialectJSONSchema
+}
+
+// draft returns the JSON Schema draft of the document containing schema.
+func (c *RuleContext) draft(schema *Schema) Draft {
+	if doc, ok := c.resolver.docOf[schema]; ok {
+		return doc.draft
+	}
+	return Draft2020
+}
+
+// Report records an issue. An empty Code or Severity defaults to the running
+// rule's ID and default severity.
+func (c *RuleContext) Report(issue Issue) {
+	if issue.Code == "" {
+		issue.Code = c.rule.ID()
+	}
+	if issue.Severity == "" {
+		issue.Severity = c.rule.DefaultSeverity()
+	}
+	c.result.Issues = append(c.result.Issues, issue)
+}
+
+// check runs rules against node.
+func (c *RuleContext) check(rules []Rule, node *Node) {
+	for _, rule := range rules {
+		c.rule = rule
+		rule.Check(c, node)
+	}
+	c.rule = nil
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Rule
+)
+
+// Register adds rule to the set of rules run by every Linter. It panics if a
+// rule with the same ID is already registered or the ID is a built-in issue
+// code, so that conflicts surface at startup.
+func Register(rule Rule) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	id := rule.ID()
+	if id == "" {
+		panic("linter: Register rule with empty ID")
+	}
+	for _, r := range registry {
+		if r.ID() == id {
+			panic(fmt.Sprintf("linter: Register called twice for rule %s", id))
+		}
+	}
+	if _, ok := lookupIssueCodeInfo(id); ok && !isBuiltinRule(rule) {
+		panic(fmt.Sprintf("linter: rule %s conflicts with a built-in issue code", id))
+	}
+	registry = append(registry, rule)
+}
+
+// Rules returns the registered rules in registration order.
+func Rules() []Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Clone(registry)
+}
+
+// lookupRule returns the registered rule with id.
+func lookupRule(id IssueCode) (Rule, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, r := range registry {
+		if r.ID() == id {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// rulesFor returns the registered rules that apply to profile.
+func rulesFor(profile Profile) []Rule {
+	var rules []Rule
+	for _, rule := range Rules() {
+		if profiles := rule.Profiles(); len(profiles) == 0 || slices.Contains(profiles, profile) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,123 @@
+package linter
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	codeTestTitle  IssueCode = "test-missing-title"
+	profileTestOrg Profile   = "test-org"
+)
+
+// titleRule is a custom rule requiring a title on every definition.
+type titleRule struct{}
+
+func (titleRule) ID() IssueCode             { return codeTestTitle }
+func (titleRule) DefaultSeverity() Severity { return SeverityWarning }
+func (titleRule) Description() string       { return "Definition has no title" }
+func (titleRule) Profiles() []Profile       { return []Profile{profileTestOrg} }
+
+func (titleRule) Check(ctx *RuleContext, node *Node) {
+	if strings.HasPrefix(node.Path, "$/$defs/") && strings.Count(node.Path, "/") == 2 && node.Schema.Title == "" {
+		ctx.Report(Issue{Path: node.Path, Message: "Definition has no title"})
+	}
+}
+
+func init() {
+	Register(titleRule{})
+}
+
+func TestCustomRule(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Named": {"title": "Named", "type": "string"},
+			"Anonymous": {"type": "string"}
+		}
+	}`
+
+	config := DefaultConfig()
+	config.Profile = profileTestOrg
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected profile from registered rule to be valid: %v", err)
+	}
+
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got: %v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Code != codeTestTitle || issue.Severity != SeverityWarning || issue.Path != "$/$defs/Anonymous" {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+
+	// Rules only run in the profiles they apply to
+	result, err = NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("Expected no issues in the default profile, got: %v", result.Issues)
+	}
+}
+
+func TestCustomRuleMetadata(t *testing.T) {
+	if !slices.Contains(IssueCodes(), codeTestTitle) {
+		t.Error("Expected registered rule in IssueCodes")
+	}
+	if codeTestTitle.Description() != "Definition has no title" || codeTestTitle.DefaultSeverity() != SeverityWarning {
+		t.Errorf("Unexpected metadata for %s", codeTestTitle)
+	}
+
+	config := DefaultConfig()
+	config.Profile = profileTestOrg
+	config.Rules = map[IssueCode]Severity{codeTestTitle: SeverityError}
+	result, err := New(config).Lint([]byte(`{"$defs": {"Anonymous": {"type": "string"}}}`))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Severity != SeverityError {
+		t.Errorf("Expected severity override to apply to custom rule, got: %v", result.Issues)
+	}
+}
+
+func TestRegisterConflicts(t *testing.T) {
+	for _, rule := range []Rule{titleRule{}, namedRule(CodeUnusedSuppression), namedRule(CodeLargeUnion)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected Register(%s) to panic", rule.ID())
+				}
+			}()
+			Register(rule)
+		}()
+	}
+}
+
+func TestBuiltinRules(t *testing.T) {
+	var ids []IssueCode
+	for _, rule := range Rules() {
+		ids = append(ids, rule.ID())
+		if rule.Description() == "" || rule.DefaultSeverity() == "" {
+			t.Errorf("Rule %s lacks metadata", rule.ID())
+		}
+	}
+	for _, code := range []IssueCode{CodeUnionNoDiscriminator, CodeCircularReference, CodeMixedTypeDisallowed} {
+		if !slices.Contains(ids, code) {
+			t.Errorf("Expected built-in rule %s to be registered", code)
+		}
+	}
+}
+
+// namedRule is a rule with a given ID and no checks.
+type namedRule IssueCode
+
+func (r namedRule) ID() IssueCode           { return IssueCode(r) }
+func (namedRule) DefaultSeverity() Severity { return SeverityWarning }
+func (namedRule) Description() string       { return "test" }
+func (namedRule) Profiles() []Profile       { return nil }
+func (namedRule) Check(*RuleContext, *Node) {}
@@ -0,0 +1,924 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// builtinRule is a rule shipped with schemalint. Its default severity and
+// description come from the issue code table.
+type builtinRule struct {
+	id       IssueCode
+	profiles []Profile
+	check    func(ctx *RuleContext, node *Node)
+}
+
+func (r *builtinRule) ID() IssueCode { return r.id }
+
+func (r *builtinRule) DefaultSeverity() Severity {
+	info, _ := lookupIssueCodeInfo(r.id)
+	return info.severity
+}
+
+func (r *builtinRule) Description() string {
+	info, _ := lookupIssueCodeInfo(r.id)
+	return info.description
+}
+
+func (r *builtinRule) Profiles() []Profile { return r.profiles }
+
+func (r *builtinRule) Check(ctx *RuleContext, node *Node) { r.check(ctx, node) }
+
+// isBuiltinRule reports whether rule is one of the rules shipped with schemalint.
+func isBuiltinRule(rule Rule) bool {
+	_, ok := rule.(*builtinRule)
+	return ok
+}
+
+// scaleOnly restricts a built-in rule to the scale profile.
+var scaleOnly = []Profile{ProfileScale}
+
+// goOnly restricts a built-in rule to the go profile.
+var goOnly = []Profile{ProfileGo}
+
+// typeScriptOnly restricts a built-in rule to the typescript profile.
+var typeScriptOnly = []Profile{ProfileTypeScript}
+
+// rustOnly restricts a built-in rule to the rust profile.
+var rustOnly = []Profile{ProfileRust}
+
+// protobufOnly restricts a built-in rule to the protobuf profile.
+var protobufOnly = []Profile{ProfileProtobuf}
+
+// pythonOnly restricts a built-in rule to the python profile.
+var pythonOnly = []Profile{ProfilePython}
+
+func init() {
+	for _, rule := range []*builtinRule{
+		{id: CodeUnionNoDiscriminator, check: checkUnionDiscriminator},
+		{id: CodeMissingConst, check: checkMissingConst},
+		{id: CodeDuplicateConstValue, check: checkDuplicateConstValue},
+		{id: CodeInvalidPropertyCase, check: checkPropertyCase},
+		{id: CodeLargeUnion, check: checkLargeUnion},
+		{id: CodeNestedUnion, check: checkNestedUnion},
+		{id: CodeAdditionalProps, check: checkVariantAdditionalProperties},
+		{id: CodeCircularReference, check: checkCircularReferences},
+		{id: CodeNullableForm, check: checkNullableForm},
+		{id: CodeDraftKeyword, check: checkDraftKeywords},
+		{id: CodeCompositionDisallowed, profiles: scaleOnly, check: checkComposition},
+		{id: CodeAdditionalPropsDisallowed, profiles: scaleOnly, check: checkAdditionalPropertiesDisallowed},
+		{id: CodeMissingType, profiles: scaleOnly, check: checkMissingType},
+		{id: CodeMixedTypeDisallowed, profiles: scaleOnly, check: checkMixedType},
+		{id: CodeGoIdentifierCollision, profiles: goOnly, check: checkGoIdentifierCollision},
+		{id: CodeGoIdentifier, profiles: goOnly, check: checkGoIdentifier},
+		{id: CodeGoIntegerWidth, profiles: goOnly, check: checkGoIntegerWidth},
+		{id: CodeGoDefault, profiles: goOnly, check: checkGoDefault},
+		{id: CodeTSOptionalDiscriminator, profiles: typeScriptOnly, check: checkTSOptionalDiscriminator},
+		{id: CodeTSIndexSignature, profiles: typeScriptOnly, check: checkTSIndexSignature},
+		{id: CodeTSPropertyQuoting, profiles: typeScriptOnly, check: checkTSPropertyQuoting},
+		{id: CodeRustSerdeRepresentation, profiles: rustOnly, check: checkRustSerdeRepresentation},
+		{id: CodeRustUnrepresentableUnion, profiles: rustOnly, check: checkRustUnrepresentableUnion},
+		{id: CodeProtoOneof, profiles: protobufOnly, check: checkProtoOneof},
+		{id: CodeProtoMap, profiles: protobufOnly, check: checkProtoMap},
+		{id: CodeProtoNestedArray, profiles: protobufOnly, check: checkProtoNestedArray},
+		{id: CodeProtoNullableScalar, profiles: protobufOnly, check: checkProtoNullableScalar},
+		{id: CodeProtoEnumZero, profiles: protobufOnly, check: checkProtoEnumZero},
+		{id: CodePythonDiscriminator, profiles: pythonOnly, check: checkPythonDiscriminator},
+		{id: CodePythonPropertyName, profiles: pythonOnly, check: checkPythonPropertyName},
+		{id: CodePythonMixedEnum, profiles: pythonOnly, check: checkPythonMixedEnum},
+	} {
+		Register(rule)
+	}
+}
+
+// union is an anyOf or oneOf of a schema with its variants resolved.
+type union struct {
+	keyword       string
+	path          string
+	variants      []*Schema
+	resolved      []unionVariant
+	discriminator *discriminatorInfo
+}
+
+// unionsOf returns the unions of node that union rules check. Nullable
+// patterns (anyOf [T, null]) and unions whose variants could not be resolved
+// (e.g. external $refs) are skipped.
+func (c *RuleContext) unionsOf(node *Node) []*union {
+	if unions, ok := c.unions[node.Schema]; ok {
+		return unions
+	}
+	var unions []*union
+	for _, u := range []struct {
+		keyword  string
+		variants []*Schema
+	}{{"anyOf", node.Schema.AnyOf}, {"oneOf", node.Schema.OneOf}} {
+		if len(u.variants) == 0 || isNullablePattern(u.variants) {
+			continue
+		}
+		path := node.Path + "/" + u.keyword
+		resolved := resolveVariants(u.variants, path, c.resolver)
+		if allUnresolved(resolved) {
+			continue
+		}
+		unions = append(unions, &union{
+			keyword:       u.keyword,
+			path:          path,
+			variants:      u.variants,
+			resolved:      resolved,
+			discriminator: findDiscriminator(node.Schema.Discriminator, resolved, c.config.DiscriminatorFields, c.config.Profile == ProfileTypeScript),
+		})
+	}
+	c.unions[node.Schema] = unions
+	return unions
+}
+
+// checkUnionDiscriminator reports unions without a discriminator field. In
+// the rust profile, unions with a serde representation are left to the rust
+// rules, which report the ones serde cannot tell apart.
+func checkUnionDiscriminator(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		if ctx.config.Profile == ProfileRust && serdeEnumOf(u) != nil {
+			continue
+		}
+		if u.discriminator == nil && len(u.variants) > 1 && !isReferencePattern(u.resolved) {
+			ctx.Report(Issue{
+				Path:       u.path,
+				Message:    fmt.Sprintf("%s union has no discriminator field", u.keyword),
+				Suggestion: "Add a const property (e.g., 'type' or 'kind') to each variant with a unique value",
+			})
+		}
+	}
+}
+
+// reportOptionalDiscriminators reports union variants that declare the
+// union's discriminator property without requiring it; consequence says
+// what that means for the generated code.
+func reportOptionalDiscriminators(ctx *RuleContext, node *Node, consequence string) {
+	for _, u := range ctx.unionsOf(node) {
+		if u.discriminator == nil {
+			continue
+		}
+		field := u.discriminator.fieldName
+		for _, variant := range u.resolved {
+			if variant.schema == nil || variant.schema.Properties[field] == nil || slices.Contains(variant.schema.Required, field) {
+				continue
+			}
+			ctx.Report(Issue{
+				File:       variant.file,
+				Path:       fmt.Sprintf("%s/properties/%s", variant.path, EscapePointerToken(field)),
+				Message:    fmt.Sprintf("Discriminator '%s' is not required, so %s", field, consequence),
+				Suggestion: fmt.Sprintf("Add '%s' to the variant's required list", field),
+			})
+		}
+	}
+}
+
+// checkMissingConst reports variants lacking a const value for the union's discriminator.
+func checkMissingConst(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		if u.discriminator == nil {
+			continue
+		}
+		field := u.discriminator.fieldName
+		for _, variant := range u.resolved {
+			if variant.schema == nil {
+				continue
+			}
+			prop, ok := variant.schema.Properties[field]
+			if u.discriminator.explicit {
+				// An OpenAPI discriminator needs no const values, and the
+				// property is commonly inherited through allOf
+				if !hasProperty(ctx, variant.schema, field, 0) {
+					ctx.Report(Issue{
+						File:       variant.file,
+						Path:       variant.path,
+						Message:    fmt.Sprintf("Variant missing discriminator property '%s'", field),
+						Suggestion: fmt.Sprintf("Add the '%s' property to this variant or a schema it includes with allOf", field),
+					})
+				}
+				continue
+			}
+			if !ok || prop == nil {
+				ctx.Report(Issue{
+					File:       variant.file,
+					Path:       variant.path,
+					Message:    fmt.Sprintf("Variant missing discriminator property '%s'", field),
+					Suggestion: fmt.Sprintf("Add '%s' property with a const value to this variant", field),
+				})
+				continue
+			}
+			if prop.Const == nil {
+				ctx.Report(Issue{
+					File:       variant.file,
+					Path:       fmt.Sprintf("%s/properties/%s", variant.path, EscapePointerToken(field)),
+					Message:    fmt.Sprintf("Discriminator property '%s' has no const value", field),
+					Suggestion: fmt.Sprintf("Add 'const' to the '%s' property with a unique string value", field),
+				})
+			}
+		}
+	}
+}
+
+// hasProperty reports whether schema declares property name, directly or
+// through its allOf members.
+func hasProperty(ctx *RuleContext, schema *Schema, name string, depth int) bool {
+	if schema == nil || depth > maxRefChain {
+		return false
+	}
+	if schema.Ref != "" {
+		target, err := ctx.Resolve(schema)
+		if err != nil {
+			// Give unresolvable references the benefit of the doubt
+			return true
+		}
+		schema = target.Schema
+	}
+	if _, ok := schema.Properties[name]; ok {
+		return true
+	}
+	for _, member := range schema.AllOf {
+		if hasProperty(ctx, member, name, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDuplicateConstValue reports variants sharing a discriminator value.
+func checkDuplicateConstValue(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		if u.discriminator == nil {
+			continue
+		}
+		field := u.discriminator.fieldName
+		seenValues := make(map[string]bool)
+		for _, variant := range u.resolved {
+			if variant.schema == nil {
+				continue
+			}
+			prop := variant.schema.Properties[field]
+			if prop == nil {
+				continue
+			}
+			key, ok := discriminatorKey(prop.Const, ctx.config.Profile == ProfileTypeScript)
+			if !ok {
+				continue
+			}
+			if seenValues[key] {
+				ctx.Report(Issue{
+					File:       variant.file,
+					Path:       fmt.Sprintf("%s/properties/%s", variant.path, EscapePointerToken(field)),
+					Message:    fmt.Sprintf("Duplicate discriminator value '%v'", prop.Const),
+					Suggestion: "Each variant must have a unique const value for the discriminator",
+				})
+			}
+			seenValues[key] = true
+		}
+	}
+}
+
+// checkLargeUnion reports unions with more variants than configured.
+func checkLargeUnion(ctx *RuleContext, node *Node) {
+	limit := ctx.config.MaxUnionVariants
+	for _, u := range ctx.unionsOf(node) {
+		if len(u.variants) > limit {
+			ctx.Report(Issue{
+				Path:       u.path,
+				Message:    fmt.Sprintf("Union has %d variants (threshold: %d)", len(u.variants), limit),
+				Suggestion: "Consider splitting into smaller, more focused unions",
+			})
+		}
+	}
+}
+
+// checkNestedUnion reports unions nested deeper than configured.
+func checkNestedUnion(ctx *RuleContext, node *Node) {
+	limit := ctx.config.MaxUnionNestingDepth
+	if node.UnionDepth < limit {
+		return
+	}
+	for _, u := range ctx.unionsOf(node) {
+		ctx.Report(Issue{
+			Path:       u.path,
+			Message:    fmt.Sprintf("Union nested %d levels deep (threshold: %d)", node.UnionDepth+1, limit),
+			Suggestion: "Flatten the union hierarchy for better Go compatibility",
+		})
+	}
+}
+
+// checkVariantAdditionalProperties reports union variants that allow additional properties.
+func checkVariantAdditionalProperties(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		for _, variant := range u.resolved {
+			if variant.schema == nil {
+				continue
+			}
+			if variant.schema.AdditionalProperties != nil && *variant.schema.AdditionalProperties {
+				issue := Issue{
+					File:       variant.file,
+					Path:       variant.path,
+					Message:    "Union variant has additionalProperties: true",
+					Suggestion: "Set additionalProperties: false to avoid ambiguous JSON decoding",
+				}
+				// A schema-valued additionalProperties describes a map; only
+				// the literal true is safe to rewrite
+				if variant.schema.AdditionalPropertiesSchema == nil {
+					issue.Fix = []PatchOperation{{
+						Op:    "replace",
+						Path:  strings.TrimPrefix(variant.path, "$") + "/additionalProperties",
+						Value: jsonValue(false),
+					}}
+				}
+				ctx.Report(issue)
+			}
+		}
+	}
+}
+
+// checkCircularReferences checks the document's reference graph for cycles,
+// once per document at its first root schema.
+func checkCircularReferences(ctx *RuleContext, node *Node) {
+	if !node.IsRoot() {
+		return
+	}
+	if doc := ctx.resolver.docOf[node.Schema]; doc != nil && doc.schemas[0].schema == node.Schema {
+		lintCycles(ctx, doc)
+	}
+}
+
+// checkPropertyCase checks the casing of property names.
+func checkPropertyCase(ctx *RuleContext, node *Node) {
+	propertyCase := ctx.config.PropertyCase
+	if propertyCase == CaseNone {
+		return
+	}
+	for propName := range node.Schema.Properties {
+		if matchesCase(propName, propertyCase) {
+			continue
+		}
+		issue := Issue{
+			Path:       fmt.Sprintf("%s/properties/%s", node.Path, EscapePointerToken(propName)),
+			Message:    fmt.Sprintf("Property '%s' is not in %s", propName, propertyCase),
+			Suggestion: fmt.Sprintf("Rename property to follow the %s convention", propertyCase),
+		}
+		if newName := convertCase(propName, propertyCase); newName != "" && matchesCase(newName, propertyCase) {
+			issue.Suggestion = fmt.Sprintf("Rename property to '%s' to follow the %s convention", newName, propertyCase)
+			// Renaming onto an existing property would merge two properties
+			if _, exists := node.Schema.Properties[newName]; !exists {
+				issue.Fix = renamePropertyFix(node.Schema, node.Pointer, propName, newName)
+			}
+		}
+		ctx.Report(issue)
+	}
+}
+
+// matchesCase reports whether name follows the property case convention.
+func matchesCase(name string, propertyCase PropertyCase) bool {
+	switch propertyCase {
+	case CaseCamel:
+		return isCamelCase(name)
+	case CaseSnake:
+		return isSnakeCase(name)
+	case CaseKebab:
+		return isKebabCase(name)
+	case CasePascal:
+		return isPascalCase(name)
+	}
+	return true
+}
+
+// checkComposition disallows composition keywords (anyOf, oneOf, allOf).
+// Nullable patterns are not composition: anyOf [T, null], and a single $ref
+// wrapped to add nullable: true (OpenAPI 3.0 ignores siblings of $ref).
+func checkComposition(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	for _, c := range []struct {
+		keyword    string
+		members    []*Schema
+		suggestion string
+	}{
+		{"anyOf", schema.AnyOf, "Use separate schema definitions instead of unions"},
+		{"oneOf", schema.OneOf, "Use separate schema definitions instead of unions"},
+		{"allOf", schema.AllOf, "Flatten the schema structure instead of using composition"},
+	} {
+		if len(c.members) == 0 || isNullablePattern(c.members) || isNullableRef(schema, c.members) {
+			continue
+		}
+		ctx.Report(Issue{
+			Path:       node.Path + "/" + c.keyword,
+			Message:    c.keyword + " is disallowed in scale profile",
+			Suggestion: c.suggestion,
+		})
+	}
+}
+
+// isNullableRef checks for a single $ref made nullable with nullable: true or
+// x-nullable, e.g. {"nullable": true, "allOf": [{"$ref": "..."}]}.
+func isNullableRef(schema *Schema, members []*Schema) bool {
+	nullable := (schema.Nullable != nil && *schema.Nullable) || (schema.XNullable != nil && *schema.XNullable)
+	return nullable && len(members) == 1 && members[0] != nil && members[0].Ref != ""
+}
+
+// checkAdditionalPropertiesDisallowed disallows additionalProperties: true.
+func checkAdditionalPropertiesDisallowed(ctx *RuleContext, node *Node) {
+	if ap := node.Schema.AdditionalProperties; ap != nil && *ap {
+		ctx.Report(Issue{
+			Path:       node.Path,
+			Message:    "additionalProperties: true is disallowed in scale profile",
+			Suggestion: "Set additionalProperties: false or remove it to ensure strict type mapping",
+		})
+	}
+}
+
+// checkMissingType requires an explicit type (unless it's a $ref, boolean schema or container).
+func checkMissingType(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	if schema.HasType() || schema.IsRef() || schema.IsBooleanSchema {
+		return
+	}
+	// Only report if this is a meaningful schema (has properties, items, etc.)
+	if schema.IsObject() || schema.IsArray() || schema.Const != nil || len(schema.Enum) > 0 {
+		ctx.Report(Issue{
+			Path:       node.Path,
+			Message:    "missing explicit type field in scale profile",
+			Suggestion: "Add a 'type' field to specify the schema type",
+		})
+	}
+}
+
+// checkMixedType disallows mixed types (type arrays like ["string", "number"]).
+func checkMixedType(ctx *RuleContext, node *Node) {
+	if node.Schema.HasMixedType() {
+		issue := Issue{
+			Path:       node.Path,
+			Message:    fmt.Sprintf("mixed type array %v is disallowed in scale profile", node.Schema.TypeList),
+			Suggestion: "Use a single type; for nullable types, use a separate null check",
+		}
+		// OpenAPI 3.0 and Swagger 2 have no null type; nullable-form suggests
+		// their nullable keyword there
+		if t, ok := nullableType(node.Schema.TypeList); ok && ctx.dialect(node.Schema) != dialectOpenAPI30 && ctx.dialect(node.Schema) != dialectSwagger2 {
+			issue.Message = fmt.Sprintf("nullable type array %v is disallowed in scale profile", node.Schema.TypeList)
+			issue.Suggestion = fmt.Sprintf(`Use anyOf: [{"type": %q}, {"type": "null"}]`, t)
+			// Rewrite type [T, "null"] in place as anyOf [{type: T}, {type: null}]
+			issue.Fix = []PatchOperation{
+				{Op: "move", From: node.Pointer + "/type", Path: node.Pointer + "/anyOf"},
+				{Op: "replace", Path: node.Pointer + "/anyOf", Value: jsonValue([]map[string]string{{"type": t}, {"type": "null"}})},
+			}
+		}
+		ctx.Report(issue)
+	}
+}
+
+// checkNullableForm reports nullability expressed in a form the document's
+// dialect does not use: OpenAPI 3.0 and Swagger 2 have no null type and
+// expect nullable: true and x-nullable: true, while JSON Schema and OpenAPI
+// 3.1 ignore nullable and x-nullable.
+func checkNullableForm(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	switch ctx.dialect(schema) {
+	case dialectOpenAPI30:
+		checkNullableKeyword(ctx, node, "OpenAPI 3.0", "nullable", "x-nullable", "a Swagger 2 extension")
+		return
+	case dialectSwagger2:
+		checkNullableKeyword(ctx, node, "Swagger 2", "x-nullable", "nullable", "an OpenAPI 3.0 keyword")
+		return
+	}
+
+	for _, keyword := range []struct {
+		name  string
+		value *bool
+	}{{"nullable", schema.Nullable}, {"x-nullable", schema.XNullable}} {
+		if keyword.value == nil || !*keyword.value {
+			continue
+		}
+		issue := Issue{
+			Path:    node.Path + "/" + keyword.name,
+			Message: fmt.Sprintf("%s: true is not a JSON Schema keyword and is ignored by validators", keyword.name),
+		}
+		remove := PatchOperation{Op: "remove", Path: node.Pointer + "/" + keyword.name}
+		switch {
+		case slices.Contains(schema.TypeList, "null") || isNullablePattern(schema.AnyOf) || isNullablePattern(schema.OneOf):
+			issue.Suggestion = fmt.Sprintf("Remove %s; the schema already allows null", keyword.name)
+			issue.Fix = []PatchOperation{remove}
+		case schema.Type != "" && len(schema.TypeList) == 0 && ctx.config.Profile != ProfileScale:
+			issue.Suggestion = fmt.Sprintf(`Use type: [%q, "null"]`, schema.Type)
+			issue.Fix = []PatchOperation{remove, {
+				Op:    "replace",
+				Path:  node.Pointer + "/type",
+				Value: jsonValue([]string{schema.Type, "null"}),
+			}}
+		default:
+			issue.Suggestion = `Use anyOf: [<schema>, {"type": "null"}]`
+		}
+		ctx.Report(issue)
+	}
+}
+
+// checkNullableKeyword reports nullable forms other than keyword: true in a
+// dialect, such as OpenAPI 3.0, that has no null type and marks schemas
+// nullable with keyword. other is the other dialect's nullable keyword,
+// described by otherKind.
+func checkNullableKeyword(ctx *RuleContext, node *Node, dialect, keyword, other, otherKind string) {
+	schema := node.Schema
+	values := map[string]*bool{"nullable": schema.Nullable, "x-nullable": schema.XNullable}
+	if value := values[other]; value != nil && *value {
+		issue := Issue{
+			Path:       node.Path + "/" + other,
+			Message:    fmt.Sprintf("%s is %s; %s uses %s: true", other, otherKind, dialect, keyword),
+			Suggestion: fmt.Sprintf("Replace %s with %s", other, keyword),
+		}
+		if values[keyword] == nil {
+			issue.Fix = []PatchOperation{{Op: "move", From: node.Pointer + "/" + other, Path: node.Pointer + "/" + keyword}}
+		}
+		ctx.Report(issue)
+	}
+
+	if slices.Contains(schema.TypeList, "null") {
+		issue := Issue{
+			Path:       node.Path + "/type",
+			Message:    fmt.Sprintf("%s has no null type", dialect),
+			Suggestion: fmt.Sprintf("Use a single type with %s: true", keyword),
+		}
+		if t, ok := nullableType(schema.TypeList); ok && values[keyword] == nil {
+			issue.Suggestion = fmt.Sprintf("Use type: %q with %s: true", t, keyword)
+			issue.Fix = []PatchOperation{
+				{Op: "replace", Path: node.Pointer + "/type", Value: jsonValue(t)},
+				{Op: "add", Path: node.Pointer + "/" + keyword, Value: jsonValue(true)},
+			}
+		}
+		ctx.Report(issue)
+	}
+
+	for _, u := range []struct {
+		keyword  string
+		variants []*Schema
+	}{{"anyOf", schema.AnyOf}, {"oneOf", schema.OneOf}} {
+		if isNullablePattern(u.variants) {
+			ctx.Report(Issue{
+				Path:       node.Path + "/" + u.keyword,
+				Message:    fmt.Sprintf("%s has no null type", dialect),
+				Suggestion: fmt.Sprintf("Use %s: true on the schema; wrap a $ref in allOf to make it nullable", keyword),
+			})
+		}
+	}
+}
+
+// nullableType returns T for a type array of the form [T, "null"] (in either order).
+func nullableType(types []string) (string, bool) {
+	if len(types) != 2 {
+		return "", false
+	}
+	switch {
+	case types[1] == "null" && types[0] != "null":
+		return types[0], true
+	case types[0] == "null" && types[1] != "null":
+		return types[1], true
+	}
+	return "", false
+}
+
+// checkDraftKeywords reports keywords the document's draft does not define,
+// or defines in another form: id and $id, boolean and numeric exclusive
+// bounds, array-form items and prefixItems, and the keywords added or
+// removed between drafts. In drafts before 2019-09 it also reports keywords
+// next to $ref, which those drafts ignore.
+func checkDraftKeywords(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	draft := ctx.draft(schema)
+	has := func(keyword string) bool { return slices.Contains(schema.keys, keyword) }
+	move := func(from, to string) PatchOperation {
+		return PatchOperation{Op: "move", From: node.Pointer + "/" + from, Path: node.Pointer + "/" + to}
+	}
+
+	for _, kw := range draftKeywords {
+		defined := !draft.before(kw.since) && (kw.until == "" || !kw.until.before(draft))
+		if defined || !has(kw.keyword) || (kw.keyword == "additionalItems" && schema.ItemsList != nil) {
+			continue
+		}
+		issue := Issue{
+			Path:    node.Path + "/" + kw.keyword,
+			Message: fmt.Sprintf("%s is not defined in %s", kw.keyword, draft),
+		}
+		if kw.instead != "" {
+			issue.Suggestion = fmt.Sprintf("Use %s", kw.instead)
+		}
+		switch {
+		case kw.keyword == "id" && !has("$id"):
+			issue.Fix = []PatchOperation{move("id", "$id")}
+		case kw.keyword == "$id" && !has("id"):
+			issue.Fix = []PatchOperation{move("$id", "id")}
+		case kw.keyword == "prefixItems" && !has("additionalItems"):
+			if has("items") {
+				issue.Fix = append(issue.Fix, move("items", "additionalItems"))
+			}
+			issue.Fix = append(issue.Fix, move("prefixItems", "items"))
+		}
+		ctx.Report(issue)
+	}
+
+	if schema.ItemsList != nil && !draft.before(Draft2020) {
+		issue := Issue{
+			Path:       node.Path + "/items",
+			Message:    fmt.Sprintf("array-form items is not defined in %s; items must be a single schema", draft),
+			Suggestion: "Use prefixItems for the tuple, and items instead of additionalItems",
+			Fix:        []PatchOperation{move("items", "prefixItems")},
+		}
+		if has("additionalItems") {
+			issue.Fix = append(issue.Fix, move("additionalItems", "items"))
+		}
+		if has("prefixItems") {
+			issue.Fix = nil
+		}
+		ctx.Report(issue)
+	}
+
+	for _, bound := range []struct {
+		keyword, inclusive string
+		number             *json.Number
+		flag               *bool
+		limit              *json.Number
+	}{
+		{"exclusiveMinimum", "minimum", schema.ExclusiveMinimum, schema.ExclusiveMinimumFlag, schema.Minimum},
+		{"exclusiveMaximum", "maximum", schema.ExclusiveMaximum, schema.ExclusiveMaximumFlag, schema.Maximum},
+	} {
+		switch {
+		case bound.flag != nil && draft != Draft4:
+			issue := Issue{
+				Path:       node.Path + "/" + bound.keyword,
+				Message:    fmt.Sprintf("boolean %s is a draft-04 form; in %s it is the exclusive bound itself", bound.keyword, draft),
+				Suggestion: fmt.Sprintf("Set %s to the bound instead of %s", bound.keyword, bound.inclusive),
+			}
+			switch {
+			case !*bound.flag:
+				issue.Suggestion = fmt.Sprintf("Remove %s: false", bound.keyword)
+				issue.Fix = []PatchOperation{{Op: "remove", Path: node.Pointer + "/" + bound.keyword}}
+			case bound.limit != nil:
+				issue.Fix = []PatchOperation{
+					{Op: "remove", Path: node.Pointer + "/" + bound.inclusive},
+					{Op: "replace", Path: node.Pointer + "/" + bound.keyword, Value: jsonValue(*bound.limit)},
+				}
+			}
+			ctx.Report(issue)
+		case bound.number != nil && draft == Draft4:
+			issue := Issue{
+				Path:       node.Path + "/" + bound.keyword,
+				Message:    fmt.Sprintf("numeric %s is not defined in draft-04, which uses a boolean alongside %s", bound.keyword, bound.inclusive),
+				Suggestion: fmt.Sprintf("Use %s: %v with %s: true", bound.inclusive, *bound.number, bound.keyword),
+			}
+			if bound.limit == nil {
+				issue.Fix = []PatchOperation{
+					{Op: "replace", Path: node.Pointer + "/" + bound.keyword, Value: jsonValue(true)},
+					{Op: "add", Path: node.Pointer + "/" + bound.inclusive, Value: jsonValue(*bound.number)},
+				}
+			}
+			ctx.Report(issue)
+		}
+	}
+
+	if schema.Ref != "" && draft.before(Draft2019) {
+		var ignored []string
+		for _, keyword := range schema.keys {
+			if !slices.Contains(refAnnotations, keyword) && !strings.HasPrefix(keyword, "x-") {
+				ignored = append(ignored, keyword)
+			}
+		}
+		if len(ignored) > 0 {
+			ctx.Report(Issue{
+				Path:       node.Path + "/$ref",
+				Message:    fmt.Sprintf("keywords next to $ref are ignored in %s: %s", draft, strings.Join(ignored, ", ")),
+				Suggestion: "Combine the $ref and the other keywords with allOf",
+			})
+		}
+	}
+}
+
+// isCamelCase checks if a string is in camelCase.
+func isCamelCase(s string) bool {
+	if s == "" {
+		return true
+	}
+	if s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isSnakeCase checks if a string is in snake_case.
+func isSnakeCase(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// isKebabCase checks if a string is in kebab-case.
+func isKebabCase(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isPascalCase checks if a string is in PascalCase.
+func isPascalCase(s string) bool {
+	if s == "" {
+		return true
+	}
+	if s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// unionVariant is a union member after $ref resolution. schema is nil when
+// the variant could not be resolved. file is set when the variant was
+// resolved into another document.
+type unionVariant struct {
+	schema *Schema
+	path   string
+	file   string
+}
+
+// resolveVariants dereferences $ref variants. Resolved variants report issues
+// at the location of their target schema.
+func resolveVariants(variants []*Schema, path string, resolver *Resolver) []unionVariant {
+	resolved := make([]unionVariant, 0, len(variants))
+	for i, v := range variants {
+		if v == nil {
+			continue
+		}
+		if v.Ref == "" {
+			resolved = append(resolved, unionVariant{schema: v, path: fmt.Sprintf("%s/%d", path, i)})
+			continue
+		}
+		target, err := resolver.Resolve(v, v.Ref)
+		if err != nil {
+			resolved = append(resolved, unionVariant{path: fmt.Sprintf("%s/%d", path, i)})
+			continue
+		}
+		resolved = append(resolved, unionVariant{schema: target.Schema, path: "$" + target.Pointer, file: target.File})
+	}
+	return resolved
+}
+
+// allUnresolved checks if no variant could be resolved to a schema.
+func allUnresolved(variants []unionVariant) bool {
+	for _, v := range variants {
+		if v.schema != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isNullablePattern checks if this is a simple nullable pattern: anyOf [T, null]
+func isNullablePattern(variants []*Schema) bool {
+	if len(variants) != 2 {
+		return false
+	}
+	hasNull := false
+	hasType := false
+	for _, v := range variants {
+		if v == nil {
+			continue
+		}
+		if v.Type == "null" {
+			hasNull = true
+		} else if v.Type != "" || v.Ref != "" {
+			hasType = true
+		}
+	}
+	return hasNull && hasType
+}
+
+// isReferencePattern checks if this is a reference pattern: anyOf
+// [ComponentReference, BaseXxx]. One resolved variant must be a reference
+// object, one with a $component_ref property, or a schema whose name ends in
+// Reference.
+func isReferencePattern(variants []unionVariant) bool {
+	if len(variants) != 2 {
+		return false
+	}
+	for _, v := range variants {
+		if v.schema == nil {
+			continue
+		}
+		if prop, ok := v.schema.Properties["$component_ref"]; ok && prop != nil {
+			return true
+		}
+		name := v.path[strings.LastIndex(v.path, "/")+1:]
+		if strings.HasSuffix(UnescapePointerToken(name), "Reference") {
+			return true
+		}
+	}
+	return false
+}
+
+// findDiscriminator looks for a common discriminator field, from fields,
+// across variants. Discriminator values are string consts, or with literals
+// any literal const (see discriminatorKey).
+func findDiscriminator(discriminator *Discriminator, variants []unionVariant, fields []string, literals bool) *discriminatorInfo {
+	if len(variants) < 2 {
+		return nil
+	}
+
+	// An OpenAPI discriminator object names the field explicitly; values come
+	// from its mapping (or default to the variants' schema names)
+	if discriminator != nil && discriminator.PropertyName != "" {
+		values := make(map[string]int)
+		for value := range discriminator.Mapping {
+			values[value]++
+		}
+		return &discriminatorInfo{
+			fieldName: discriminator.PropertyName,
+			values:    values,
+			explicit:  true,
+		}
+	}
+
+	// Count const values for each potential discriminator field
+	candidates := make(map[string]map[string]int) // field -> const value -> count
+
+	for _, fieldName := range fields {
+		candidates[fieldName] = make(map[string]int)
+	}
+
+	resolvedVariants := 0
+	for _, variant := range variants {
+		if variant.schema == nil {
+			// Skip variants that could not be resolved
+			continue
+		}
+		resolvedVariants++
+
+		for _, fieldName := range fields {
+			if prop, ok := variant.schema.Properties[fieldName]; ok && prop != nil {
+				if key, ok := discriminatorKey(prop.Const, literals); ok {
+					candidates[fieldName][key]++
+				}
+			}
+		}
+	}
+
+	// Find a field where all resolved variants have unique const values
+	for _, fieldName := range fields {
+		values := candidates[fieldName]
+		if len(values) == resolvedVariants && resolvedVariants > 0 {
+			// Check all values are unique (count == 1)
+			allUnique := true
+			for _, count := range values {
+				if count != 1 {
+					allUnique = false
+					break
+				}
+			}
+			if allUnique {
+				return &discriminatorInfo{
+					fieldName: fieldName,
+					values:    values,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// discriminatorKey returns the key identifying a discriminator const value,
+// its JSON encoding, if the value can discriminate: a string, or with
+// literals also a number or boolean, which TypeScript narrows on as literal
+// types.
+func discriminatorKey(value any, literals bool) (string, bool) {
+	switch value.(type) {
+	case string:
+	case float64, bool:
+		if !literals {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return compactValue(value), true
+}
+
+type discriminatorInfo struct {
+	fieldName string
+	values    map[string]int
+	explicit  bool // declared by an OpenAPI discriminator object
+}
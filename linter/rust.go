@@ -0,0 +1,245 @@
+package linter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// serdeShape is what serde can tell about the values of a union variant
+// when deserializing it: their JSON types, and for objects the fields that
+// must and may be present.
+type serdeShape struct {
+	types      []string // JSON types, with integer folded into number
+	values     []any    // const or enum values, if the variant is limited to them
+	required   []string
+	properties []string
+	items      *serdeShape // item shape of an array variant, if known
+}
+
+// shapeOf returns the serde shape of a variant, or nil if it cannot be told
+// from the schema (e.g. a variant built with allOf).
+func shapeOf(schema *Schema) *serdeShape {
+	if schema == nil || schema.IsBooleanSchema {
+		return nil
+	}
+	shape := &serdeShape{required: schema.Required, properties: sortedKeys(schema.Properties)}
+	switch {
+	case schema.Const != nil:
+		shape.values = []any{schema.Const}
+	case len(schema.Enum) > 0:
+		shape.values = schema.Enum
+	}
+	types := schema.types()
+	switch {
+	case len(types) > 0:
+	case len(shape.values) > 0:
+		for _, value := range shape.values {
+			types = append(types, jsonType(value))
+		}
+	case schema.IsObject():
+		types = []string{"object"}
+	case schema.IsArray():
+		types = []string{"array"}
+	default:
+		return nil
+	}
+	for _, t := range types {
+		if t == "integer" {
+			t = "number"
+		}
+		if !slices.Contains(shape.types, t) {
+			shape.types = append(shape.types, t)
+		}
+	}
+	if schema.Items != nil {
+		shape.items = shapeOf(schema.Items)
+	}
+	return shape
+}
+
+// isPrimitive reports whether the variant's values are all JSON primitives.
+func (s *serdeShape) isPrimitive() bool {
+	return !slices.Contains(s.types, "object") && !slices.Contains(s.types, "array")
+}
+
+// swallows reports whether some value of later, a variant tried after s by
+// #[serde(untagged)], also deserializes as s. serde ignores unknown fields,
+// so an object matches s when it has every field s requires.
+func (s *serdeShape) swallows(later *serdeShape) bool {
+	if s.values != nil {
+		// A variant limited to some values is tried first on purpose, and
+		// only claims values equal to its own
+		if later.values == nil {
+			return false
+		}
+		return slices.ContainsFunc(later.values, func(v any) bool {
+			return slices.ContainsFunc(s.values, func(w any) bool { return valuesEqual(v, w) })
+		})
+	}
+	for _, t := range s.types {
+		if !slices.Contains(later.types, t) {
+			continue
+		}
+		switch t {
+		case "object":
+			if !slices.ContainsFunc(s.required, func(field string) bool { return !slices.Contains(later.properties, field) }) {
+				return true
+			}
+		case "array":
+			if s.items == nil || later.items == nil || s.items.swallows(later.items) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// serdeEnum is the serde enum representation a union maps to.
+type serdeEnum struct {
+	attribute   string // e.g. #[serde(tag = "kind")]; empty for serde's default
+	description string // e.g. "an internally tagged"
+	// unrepresentable is set for an untagged union whose variants serde
+	// cannot tell apart: values of variant later also deserialize as
+	// variant earlier, which serde tries first
+	unrepresentable bool
+	later, earlier  int
+}
+
+// serdeEnumOf returns the serde representation of a union, or nil if its
+// variants could not be resolved or their shapes told from the schemas.
+func serdeEnumOf(u *union) *serdeEnum {
+	if slices.ContainsFunc(u.resolved, func(v unionVariant) bool { return v.schema == nil }) {
+		return nil
+	}
+
+	if d := u.discriminator; d != nil {
+		if content := adjacentContent(u, d.fieldName); content != "" {
+			return &serdeEnum{
+				attribute:   fmt.Sprintf("#[serde(tag = %q, content = %q)]", d.fieldName, content),
+				description: "an adjacently tagged",
+			}
+		}
+		return &serdeEnum{attribute: fmt.Sprintf("#[serde(tag = %q)]", d.fieldName), description: "an internally tagged"}
+	}
+	if isExternallyTagged(u.resolved) {
+		return &serdeEnum{description: "an externally tagged (serde's default)"}
+	}
+
+	shapes := make([]*serdeShape, len(u.resolved))
+	for i, variant := range u.resolved {
+		if shapes[i] = shapeOf(variant.schema); shapes[i] == nil {
+			return nil
+		}
+	}
+	enum := &serdeEnum{attribute: "#[serde(untagged)]", description: "an untagged"}
+	for later := range shapes {
+		for earlier := range later {
+			if shapes[earlier].swallows(shapes[later]) {
+				enum.unrepresentable, enum.later, enum.earlier = true, later, earlier
+				return enum
+			}
+		}
+	}
+	if !slices.ContainsFunc(shapes, func(s *serdeShape) bool { return !s.isPrimitive() }) {
+		enum.description = "an untagged primitive"
+	}
+	return enum
+}
+
+// adjacentContent returns the content field of an adjacently tagged union:
+// the one property besides the tag that every variant declares.
+func adjacentContent(u *union, tag string) string {
+	content := ""
+	for _, variant := range u.resolved {
+		if len(variant.schema.Properties) != 2 || variant.schema.Properties[tag] == nil {
+			return ""
+		}
+		for name := range variant.schema.Properties {
+			if name == tag {
+				continue
+			}
+			if content != "" && name != content {
+				return ""
+			}
+			content = name
+		}
+	}
+	return content
+}
+
+// isExternallyTagged reports whether every variant is either an object with
+// a single required property, named differently in each variant, or a
+// string constant (a unit variant).
+func isExternallyTagged(variants []unionVariant) bool {
+	seen := make(map[string]bool)
+	objects := 0
+	for _, variant := range variants {
+		schema := variant.schema
+		if name, ok := schema.Const.(string); ok {
+			if seen[name] {
+				return false
+			}
+			seen[name] = true
+			continue
+		}
+		if len(schema.Properties) != 1 || len(schema.Required) != 1 || schema.Properties[schema.Required[0]] == nil || seen[schema.Required[0]] {
+			return false
+		}
+		seen[schema.Required[0]] = true
+		objects++
+	}
+	return objects > 0
+}
+
+// checkRustSerdeRepresentation reports the serde enum representation each
+// union maps to. Unions serde cannot represent are reported by
+// checkRustUnrepresentableUnion instead.
+func checkRustSerdeRepresentation(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		enum := serdeEnumOf(u)
+		if enum == nil || enum.unrepresentable {
+			continue
+		}
+		issue := Issue{
+			Path:    u.path,
+			Message: fmt.Sprintf("%s maps to %s serde enum", u.keyword, enum.description),
+		}
+		if enum.attribute != "" {
+			issue.Suggestion = fmt.Sprintf("Annotate the Rust enum with %s", enum.attribute)
+		}
+		ctx.Report(issue)
+	}
+}
+
+// checkRustUnrepresentableUnion reports unions with no discriminator whose
+// variants serde cannot tell apart: #[serde(untagged)] tries the variants
+// in order, so a later variant whose values also deserialize as an earlier
+// one is never produced.
+func checkRustUnrepresentableUnion(ctx *RuleContext, node *Node) {
+	for _, u := range ctx.unionsOf(node) {
+		enum := serdeEnumOf(u)
+		if enum == nil || !enum.unrepresentable {
+			continue
+		}
+		later, earlier := u.resolved[enum.later], u.resolved[enum.earlier]
+		ctx.Report(Issue{
+			File: later.file,
+			Path: later.path,
+			Message: fmt.Sprintf("%s has no serde representation: values of %s also deserialize as %s, which #[serde(untagged)] tries first",
+				u.keyword, variantLabel(later.path), variantLabel(earlier.path)),
+			Suggestion: "Add a const discriminator property to each variant, or make the variants' JSON types or required properties distinct",
+		})
+	}
+}
+
+// variantLabel names a union variant in a message by the last token of its path.
+func variantLabel(path string) string {
+	token := path[strings.LastIndex(path, "/")+1:]
+	if strings.Trim(token, "0123456789") == "" {
+		return "variant " + token
+	}
+	return "'" + UnescapePointerToken(token) + "'"
+}
@@ -0,0 +1,94 @@
+package linter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRustSerdeRepresentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		union   string
+		code    IssueCode
+		message string
+	}{
+		{
+			name:    "internally tagged",
+			union:   `[{"type": "object", "properties": {"kind": {"const": "circle"}, "radius": {"type": "number"}}}, {"type": "object", "properties": {"kind": {"const": "square"}, "side": {"type": "number"}}}]`,
+			code:    CodeRustSerdeRepresentation,
+			message: "oneOf maps to an internally tagged serde enum",
+		},
+		{
+			name:    "adjacently tagged",
+			union:   `[{"type": "object", "properties": {"type": {"const": "circle"}, "data": {"type": "number"}}}, {"type": "object", "properties": {"type": {"const": "label"}, "data": {"type": "string"}}}]`,
+			code:    CodeRustSerdeRepresentation,
+			message: "oneOf maps to an adjacently tagged serde enum",
+		},
+		{
+			name:    "externally tagged",
+			union:   `[{"const": "Empty"}, {"type": "object", "required": ["Circle"], "properties": {"Circle": {"type": "number"}}}, {"type": "object", "required": ["Square"], "properties": {"Square": {"type": "number"}}}]`,
+			code:    CodeRustSerdeRepresentation,
+			message: "oneOf maps to an externally tagged (serde's default) serde enum",
+		},
+		{
+			name:    "untagged primitives",
+			union:   `[{"type": "string"}, {"type": "integer"}, {"type": "boolean"}]`,
+			code:    CodeRustSerdeRepresentation,
+			message: "oneOf maps to an untagged primitive serde enum",
+		},
+		{
+			name:    "untagged objects",
+			union:   `[{"type": "object", "required": ["url"], "properties": {"url": {"type": "string"}}}, {"type": "object", "required": ["path", "line"], "properties": {"path": {"type": "string"}, "line": {"type": "integer"}}}]`,
+			code:    CodeRustSerdeRepresentation,
+			message: "oneOf maps to an untagged serde enum",
+		},
+		{
+			name:    "overlapping numbers",
+			union:   `[{"type": "number"}, {"type": "integer"}]`,
+			code:    CodeRustUnrepresentableUnion,
+			message: "values of variant 1 also deserialize as variant 0",
+		},
+		{
+			name:    "enum after its type",
+			union:   `[{"type": "number"}, {"enum": [1, 2]}]`,
+			code:    CodeRustUnrepresentableUnion,
+			message: "values of variant 1 also deserialize as variant 0",
+		},
+		{
+			name:    "object without required fields",
+			union:   `[{"type": "object", "properties": {"name": {"type": "string"}}}, {"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}}}]`,
+			code:    CodeRustUnrepresentableUnion,
+			message: "values of variant 1 also deserialize as variant 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.PropertyCase = CaseNone
+			config.Profile = ProfileRust
+			result, err := New(config).Lint([]byte(`{"oneOf": ` + tt.union + `}`))
+			if err != nil {
+				t.Fatalf("Failed to lint: %v", err)
+			}
+			if len(result.Issues) != 1 {
+				t.Fatalf("Expected 1 issue, got %v", result.Issues)
+			}
+			if issue := result.Issues[0]; issue.Code != tt.code || !strings.Contains(issue.Message, tt.message) {
+				t.Errorf("Expected %s containing %q, got %v", tt.code, tt.message, issue)
+			}
+		})
+	}
+}
+
+func TestRustProfileFallsBackToDiscriminatorCheck(t *testing.T) {
+	schema := `{"oneOf": [{"allOf": [{"type": "object"}]}, {"type": "object"}]}`
+	config := DefaultConfig()
+	config.Profile = ProfileRust
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != CodeUnionNoDiscriminator {
+		t.Errorf("Expected union-no-discriminator for a union serde shapes cannot be told, got %v", result.Issues)
+	}
+}
@@ -0,0 +1,185 @@
+package linter
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "schemalint"
+	toolURI      = "https://github.com/grokify/schemalint"
+)
+
+// SARIF types cover the subset of the SARIF 2.1.0 object model emitted by schemalint.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Enabled bool   `json:"enabled"`
+	Level   string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// SARIF returns the result as a SARIF 2.1.0 log for code scanning tools.
+func (r Result) SARIF() ([]byte, error) {
+	return json.MarshalIndent(newSARIFLog([]Result{r}), "", "  ")
+}
+
+// newSARIFLog builds a single-run SARIF log covering the issues of all results.
+func newSARIFLog(results []Result) sarifLog {
+	var overrides map[IssueCode]Severity
+	if len(results) > 0 {
+		overrides = results[0].rules
+	}
+
+	codes := IssueCodes()
+	ruleIndex := make(map[IssueCode]int, len(codes))
+	rules := make([]sarifRule, len(codes))
+	for i, code := range codes {
+		severity := code.DefaultSeverity()
+		if override, ok := overrides[code]; ok {
+			severity = override
+		}
+		ruleIndex[code] = i
+		rules[i] = sarifRule{
+			ID:               string(code),
+			ShortDescription: sarifMessage{Text: code.Description()},
+			DefaultConfiguration: sarifConfiguration{
+				Enabled: severity != SeverityOff,
+				Level:   sarifLevel(severity),
+			},
+		}
+	}
+
+	sarifResults := []sarifResult{}
+	for _, r := range results {
+		for _, issue := range r.Issues {
+			sarifResults = append(sarifResults, newSARIFResult(issue, r.SchemaPath, ruleIndex))
+		}
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+}
+
+func newSARIFResult(issue Issue, schemaPath string, ruleIndex map[IssueCode]int) sarifResult {
+	result := sarifResult{
+		RuleID:              string(issue.Code),
+		RuleIndex:           ruleIndex[issue.Code],
+		Level:               sarifLevel(issue.Severity),
+		Message:             sarifMessage{Text: issue.Message},
+		PartialFingerprints: map[string]string{toolName + "/v1": issue.Fingerprint()},
+	}
+	if issue.Suggestion != "" {
+		result.Properties = map[string]string{"suggestion": issue.Suggestion}
+	}
+
+	location := sarifLocation{
+		LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: issue.Path}},
+	}
+	file := issue.File
+	if file == "" {
+		file = schemaPath
+	}
+	if file != "" {
+		location.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)},
+		}
+		if issue.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{
+				StartLine:   issue.Line,
+				StartColumn: issue.Column,
+				EndLine:     issue.EndLine,
+			}
+		}
+	}
+	result.Locations = []sarifLocation{location}
+	return result
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityOff:
+		return "none"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,52 @@
+package linter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResultSARIF(t *testing.T) {
+	result := Result{
+		SchemaPath: "schemas/api.json",
+		Issues: []Issue{
+			{Code: CodeUnionNoDiscriminator, Severity: SeverityError, Path: "$/$defs/Pet/oneOf", Message: "no discriminator", Line: 4, Column: 7, EndLine: 9},
+			{Code: CodeCircularReference, Severity: SeverityInfo, Path: "$/$defs/Node", Message: "recursive"},
+		},
+	}
+
+	data, err := result.SARIF()
+	if err != nil {
+		t.Fatalf("Failed to build SARIF: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Failed to parse SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %s", data)
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(IssueCodes()) {
+		t.Errorf("Expected %d rules, got %d", len(IssueCodes()), len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if run.Tool.Driver.Rules[first.RuleIndex].ID != string(CodeUnionNoDiscriminator) {
+		t.Errorf("ruleIndex %d does not point at %s", first.RuleIndex, first.RuleID)
+	}
+	region := first.Locations[0].PhysicalLocation.Region
+	if region == nil || region.StartLine != 4 || region.StartColumn != 7 || region.EndLine != 9 {
+		t.Errorf("Unexpected region %+v", region)
+	}
+	if first.PartialFingerprints["schemalint/v1"] != result.Issues[0].Fingerprint() {
+		t.Errorf("Unexpected fingerprint %v", first.PartialFingerprints)
+	}
+	if run.Results[1].Level != "note" {
+		t.Errorf("Expected info to map to note, got %s", run.Results[1].Level)
+	}
+}
@@ -1,18 +1,26 @@
 package linter
 
 import (
+	"bytes"
 	"encoding/json"
+	"slices"
 )
 
-// Schema represents a JSON Schema document or subschema.
-// This is a simplified representation focused on the fields needed for linting.
+// Schema represents a JSON Schema document or subschema. It models draft
+// 2020-12 along with the forms earlier drafts use for the same keywords
+// (id, array-form items, additionalItems, dependencies and boolean
+// exclusive bounds), so schemas of any draft parse; see Draft.
 type Schema struct {
 	// Core
-	Schema      string             `json:"$schema,omitempty"`
-	ID          string             `json:"$id,omitempty"`
-	Ref         string             `json:"$ref,omitempty"`
-	Defs        map[string]*Schema `json:"$defs,omitempty"`
-	Definitions map[string]*Schema `json:"definitions,omitempty"`
+	Schema        string             `json:"$schema,omitempty"`
+	ID            string             `json:"$id,omitempty"`
+	Ref           string             `json:"$ref,omitempty"`
+	Anchor        string             `json:"$anchor,omitempty"`
+	DynamicRef    string             `json:"$dynamicRef,omitempty"`
+	DynamicAnchor string             `json:"$dynamicAnchor,omitempty"`
+	Defs          map[string]*Schema `json:"$defs,omitempty"`
+	Definitions   map[string]*Schema `json:"definitions,omitempty"`
+	LegacyID      string             `json:"id,omitempty"` // draft-04 $id
 
 	// Type
 	Type     string   `json:"-"` // Handled specially for type arrays
@@ -22,15 +30,64 @@ type Schema struct {
 	AnyOf []*Schema `json:"anyOf,omitempty"`
 	OneOf []*Schema `json:"oneOf,omitempty"`
 	AllOf []*Schema `json:"allOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	// Conditional
+	If               *Schema            `json:"if,omitempty"`
+	Then             *Schema            `json:"then,omitempty"`
+	Else             *Schema            `json:"else,omitempty"`
+	DependentSchemas map[string]*Schema `json:"dependentSchemas,omitempty"`
+
+	// Dependencies holds the schema-valued members of dependencies, the
+	// form of dependentSchemas and dependentRequired before 2019-09;
+	// DependenciesRequired holds its property-name arrays.
+	Dependencies         map[string]*Schema  `json:"-"` // Handled specially
+	DependenciesRequired map[string][]string `json:"-"` // Handled specially
 
 	// Object
-	Properties                 map[string]*Schema `json:"-"` // Handled specially for boolean schemas
-	Required                   []string           `json:"required,omitempty"`
-	AdditionalProperties       *bool              `json:"-"` // Handled specially
-	AdditionalPropertiesSchema *Schema            `json:"-"` // Handled specially
+	Properties                 map[string]*Schema  `json:"-"` // Handled specially for boolean schemas
+	Required                   []string            `json:"required,omitempty"`
+	AdditionalProperties       *bool               `json:"-"` // Handled specially
+	AdditionalPropertiesSchema *Schema             `json:"-"` // Handled specially
+	PatternProperties          map[string]*Schema  `json:"patternProperties,omitempty"`
+	PropertyNames              *Schema             `json:"propertyNames,omitempty"`
+	UnevaluatedProperties      *Schema             `json:"unevaluatedProperties,omitempty"`
+	DependentRequired          map[string][]string `json:"dependentRequired,omitempty"`
+	MinProperties              *int                `json:"minProperties,omitempty"`
+	MaxProperties              *int                `json:"maxProperties,omitempty"`
 
 	// Array
-	Items *Schema `json:"items,omitempty"`
+	Items            *Schema   `json:"-"` // Handled specially; may be an array before 2020-12
+	ItemsList        []*Schema `json:"-"` // Array-form items (tuples) before 2020-12
+	PrefixItems      []*Schema `json:"prefixItems,omitempty"`
+	AdditionalItems  *Schema   `json:"additionalItems,omitempty"` // Before 2020-12
+	Contains         *Schema   `json:"contains,omitempty"`
+	UnevaluatedItems *Schema   `json:"unevaluatedItems,omitempty"`
+	MinItems         *int      `json:"minItems,omitempty"`
+	MaxItems         *int      `json:"maxItems,omitempty"`
+	UniqueItems      bool      `json:"uniqueItems,omitempty"`
+	MinContains      *int      `json:"minContains,omitempty"`
+	MaxContains      *int      `json:"maxContains,omitempty"`
+
+	// Number
+	// Numeric keywords keep the number as written, so that values beyond
+	// float64 (e.g. 1e400) are linted rather than rejected.
+	Minimum          *json.Number `json:"minimum,omitempty"`
+	Maximum          *json.Number `json:"maximum,omitempty"`
+	ExclusiveMinimum *json.Number `json:"-"` // Handled specially; draft-04 used booleans
+	ExclusiveMaximum *json.Number `json:"-"` // Handled specially; draft-04 used booleans
+	MultipleOf       *json.Number `json:"multipleOf,omitempty"`
+
+	// ExclusiveMinimumFlag and ExclusiveMaximumFlag hold the draft-04
+	// boolean forms, which make minimum and maximum exclusive.
+	ExclusiveMinimumFlag *bool `json:"-"`
+	ExclusiveMaximumFlag *bool `json:"-"`
+
+	// String
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
 
 	// Validation
 	Const any   `json:"const,omitempty"`
@@ -41,13 +98,44 @@ type Schema struct {
 	Description string `json:"description,omitempty"`
 	Default     any    `json:"default,omitempty"`
 
+	// OpenAPI
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+	Nullable      *bool          `json:"nullable,omitempty"`   // OpenAPI 3.0
+	XNullable     *bool          `json:"x-nullable,omitempty"` // Swagger 2 extension
+
 	// Extension
-	XAbstractComponent *bool `json:"x-abstract-component,omitempty"`
+	XAbstractComponent *bool        `json:"x-abstract-component,omitempty"`
+	Ignore             *Suppression `json:"-"` // x-schemalint-ignore, handled specially
+
+	// Extra holds keywords not modelled above (e.g. "examples" or "x-*"
+	// extensions), which MarshalJSON writes back unchanged.
+	Extra map[string]json.RawMessage `json:"-"`
 
 	// BooleanSchema is true if this schema is a boolean schema (true = accept all, false = reject all).
 	// When IsBooleanSchema is true, BooleanValue holds the value.
 	IsBooleanSchema bool `json:"-"`
 	BooleanValue    bool `json:"-"`
+
+	// Source layout, so MarshalJSON can reproduce the parsed document
+	keys       []string                   // keywords in source order
+	memberKeys map[string][]string        // keyword -> member names in source order, for map-valued keywords
+	source     map[string]json.RawMessage // source values of keywords that do not hold subschemas
+}
+
+// Discriminator is the OpenAPI discriminator object, which names the property
+// that selects a union variant and optionally maps its values to schemas.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// ParseSchema parses a JSON Schema document.
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
 }
 
 // UnmarshalJSON implements custom unmarshalling to handle boolean schemas and additionalProperties.
@@ -129,17 +217,105 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	// Handle items, which is an array of schemas for tuples before 2020-12
+	if itemsRaw, ok := raw["items"]; ok {
+		if bytes.HasPrefix(bytes.TrimSpace(itemsRaw), []byte("[")) {
+			if err := json.Unmarshal(itemsRaw, &s.ItemsList); err != nil {
+				return err
+			}
+		} else {
+			s.Items = &Schema{}
+			if err := json.Unmarshal(itemsRaw, s.Items); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Handle dependencies, whose members are schemas or arrays of property names
+	if depsRaw, ok := raw["dependencies"]; ok {
+		var depsMap map[string]json.RawMessage
+		if err := json.Unmarshal(depsRaw, &depsMap); err != nil {
+			return err
+		}
+		for name, depRaw := range depsMap {
+			if bytes.HasPrefix(bytes.TrimSpace(depRaw), []byte("[")) {
+				var names []string
+				if err := json.Unmarshal(depRaw, &names); err != nil {
+					return err
+				}
+				if s.DependenciesRequired == nil {
+					s.DependenciesRequired = make(map[string][]string)
+				}
+				s.DependenciesRequired[name] = names
+				continue
+			}
+			depSchema := &Schema{}
+			if err := json.Unmarshal(depRaw, depSchema); err != nil {
+				return err
+			}
+			if s.Dependencies == nil {
+				s.Dependencies = make(map[string]*Schema)
+			}
+			s.Dependencies[name] = depSchema
+		}
+	}
+
+	// Handle exclusive bounds, which are numbers or (in draft-04) booleans
+	for keyword, bound := range map[string]struct {
+		number **json.Number
+		flag   **bool
+	}{
+		"exclusiveMinimum": {&s.ExclusiveMinimum, &s.ExclusiveMinimumFlag},
+		"exclusiveMaximum": {&s.ExclusiveMaximum, &s.ExclusiveMaximumFlag},
+	} {
+		if boundRaw, ok := raw[keyword]; ok {
+			var number json.Number
+			var flag bool
+			if err := json.Unmarshal(boundRaw, &number); err == nil {
+				*bound.number = &number
+			} else if err := json.Unmarshal(boundRaw, &flag); err == nil {
+				*bound.flag = &flag
+			} else {
+				s.setExtra(keyword, boundRaw)
+			}
+		}
+	}
+
+	// Handle x-schemalint-ignore which can be bool, array of codes or object
+	if ignoreRaw, ok := raw[IgnoreKeyword]; ok {
+		suppression, err := parseSuppression(ignoreRaw)
+		if err != nil {
+			return err
+		}
+		s.Ignore = suppression
+	}
+
+	s.recordSource(data, raw)
 	return nil
 }
 
 // IsObject returns true if this schema describes an object type.
 func (s *Schema) IsObject() bool {
-	return s.Type == "object" || len(s.Properties) > 0
+	return s.Type == "object" || len(s.Properties) > 0 || len(s.PatternProperties) > 0
 }
 
 // IsArray returns true if this schema describes an array type.
 func (s *Schema) IsArray() bool {
-	return s.Type == "array" || s.Items != nil
+	return s.Type == "array" || s.Items != nil || len(s.ItemsList) > 0 || len(s.PrefixItems) > 0
+}
+
+// TupleItems returns the schemas of a tuple's leading items: prefixItems, or
+// array-form items in drafts before 2020-12.
+func (s *Schema) TupleItems() []*Schema {
+	if len(s.PrefixItems) > 0 {
+		return s.PrefixItems
+	}
+	return s.ItemsList
+}
+
+// IsConditional returns true if this schema uses if/then/else.
+func (s *Schema) IsConditional() bool {
+	return s.If != nil || s.Then != nil || s.Else != nil
 }
 
 // IsUnion returns true if this schema is a union type (anyOf or oneOf).
@@ -160,11 +336,38 @@ func (s *Schema) GetUnionVariants() []*Schema {
 	return s.OneOf
 }
 
+// IsNullable returns true if the schema accepts null in any of the forms in
+// use: type ["T", "null"], anyOf/oneOf [T, {"type": "null"}], OpenAPI 3.0
+// nullable: true, or the x-nullable extension.
+func (s *Schema) IsNullable() bool {
+	return (s.Nullable != nil && *s.Nullable) ||
+		(s.XNullable != nil && *s.XNullable) ||
+		slices.Contains(s.TypeList, "null") ||
+		isNullablePattern(s.AnyOf) || isNullablePattern(s.OneOf)
+}
+
 // HasMixedType returns true if this schema has a type array with multiple types.
 func (s *Schema) HasMixedType() bool {
 	return len(s.TypeList) > 1
 }
 
+// types returns the types the schema's type keyword names, in either form.
+func (s *Schema) types() []string {
+	if len(s.TypeList) > 0 {
+		return s.TypeList
+	}
+	if s.Type != "" {
+		return []string{s.Type}
+	}
+	return nil
+}
+
+// numberValue returns n as a float64; numbers beyond its range become ±Inf.
+func numberValue(n json.Number) float64 {
+	f, _ := n.Float64()
+	return f
+}
+
 // HasType returns true if the schema has an explicit type field.
 func (s *Schema) HasType() bool {
 	return s.Type != "" || len(s.TypeList) > 0
@@ -0,0 +1,167 @@
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// IgnoreKeyword is the schema keyword for inline suppressions.
+const IgnoreKeyword = "x-schemalint-ignore"
+
+// Suppression is an inline x-schemalint-ignore directive. It accepts three forms:
+//
+//	"x-schemalint-ignore": true                                    // all codes, this node
+//	"x-schemalint-ignore": ["union-no-discriminator"]              // listed codes, this node
+//	"x-schemalint-ignore": {"codes": [...], "subtree": true}       // listed codes, this node and nested schemas
+//
+// An issue belongs to the innermost schema whose path contains the issue path,
+// so a suppression on a schema covers issues about its own keywords (e.g. its
+// oneOf) but not issues reported on nested property or variant schemas unless
+// Subtree is set.
+type Suppression struct {
+	// Codes lists the suppressed issue codes; empty suppresses all codes.
+	Codes []IssueCode `json:"codes,omitempty"`
+	// Subtree extends the suppression to all nested schemas.
+	Subtree bool `json:"subtree,omitempty"`
+}
+
+// parseSuppression parses the value of x-schemalint-ignore.
+func parseSuppression(data json.RawMessage) (*Suppression, error) {
+	var all bool
+	if err := json.Unmarshal(data, &all); err == nil {
+		if !all {
+			return nil, nil
+		}
+		return &Suppression{}, nil
+	}
+
+	var codes []IssueCode
+	if err := json.Unmarshal(data, &codes); err == nil {
+		return &Suppression{Codes: codes}, nil
+	}
+
+	var suppression Suppression
+	if err := json.Unmarshal(data, &suppression); err != nil {
+		return nil, fmt.Errorf("invalid %s: expected true, an array of issue codes or an object", IgnoreKeyword)
+	}
+	return &suppression, nil
+}
+
+// MarshalJSON encodes the suppression in its shortest form.
+func (s *Suppression) MarshalJSON() ([]byte, error) {
+	switch {
+	case s.Subtree:
+		type suppressionObject Suppression
+		return json.Marshal((*suppressionObject)(s))
+	case len(s.Codes) == 0:
+		return []byte("true"), nil
+	default:
+		return json.Marshal(s.Codes)
+	}
+}
+
+// Suppresses returns true if the suppression covers code.
+func (s *Suppression) Suppresses(code IssueCode) bool {
+	return len(s.Codes) == 0 || slices.Contains(s.Codes, code)
+}
+
+// suppressionUse tracks which codes of a suppression matched an issue.
+type suppressionUse struct {
+	codes map[IssueCode]bool
+}
+
+// applySuppressions drops issues covered by x-schemalint-ignore directives in
+// the documents known to resolver. With reportUnused, it adds an
+// unused-suppression issue for every directive in the root document that
+// suppressed nothing.
+func applySuppressions(issues []Issue, resolver *Resolver, root *document, reportUnused bool) []Issue {
+	used := make(map[*Suppression]*suppressionUse)
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		doc, ok := resolver.document(issue.File)
+		if !ok {
+			kept = append(kept, issue)
+			continue
+		}
+		suppression := findSuppression(doc, issuePointer(issue.Path), issue.Code)
+		if suppression == nil {
+			kept = append(kept, issue)
+			continue
+		}
+		use := used[suppression]
+		if use == nil {
+			use = &suppressionUse{codes: make(map[IssueCode]bool)}
+			used[suppression] = use
+		}
+		use.codes[issue.Code] = true
+	}
+
+	if !reportUnused {
+		return kept
+	}
+
+	pointers := make([]string, 0, len(root.nodes))
+	for pointer := range root.nodes {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+	for _, pointer := range pointers {
+		suppression := root.nodes[pointer].Ignore
+		if suppression == nil {
+			continue
+		}
+		use := used[suppression]
+		path := "$" + pointer + "/" + IgnoreKeyword
+		if len(suppression.Codes) == 0 {
+			if use == nil {
+				kept = append(kept, unusedSuppressionIssue(root.file, path, "x-schemalint-ignore suppresses no issues"))
+			}
+			continue
+		}
+		var unused []string
+		for _, code := range suppression.Codes {
+			if use == nil || !use.codes[code] {
+				unused = append(unused, string(code))
+			}
+		}
+		if len(unused) > 0 {
+			kept = append(kept, unusedSuppressionIssue(root.file, path,
+				fmt.Sprintf("x-schemalint-ignore for %s suppresses no issues", strings.Join(unused, ", "))))
+		}
+	}
+	return kept
+}
+
+// findSuppression returns the directive covering an issue with code at
+// pointer, if any. The innermost schema containing pointer owns the issue;
+// enclosing schemas only apply when their suppression covers the subtree.
+func findSuppression(doc *document, pointer string, code IssueCode) *Suppression {
+	owner := true
+	for {
+		if schema, ok := doc.nodes[pointer]; ok {
+			if s := schema.Ignore; s != nil && (owner || s.Subtree) && s.Suppresses(code) {
+				return s
+			}
+			owner = false
+		}
+		if pointer == "" {
+			return nil
+		}
+		pointer = pointer[:strings.LastIndex(pointer, "/")]
+	}
+}
+
+func unusedSuppressionIssue(file, path, message string) Issue {
+	return Issue{
+		Code:       CodeUnusedSuppression,
+		Severity:   SeverityWarning,
+		File:       file,
+		Path:       path,
+		Message:    message,
+		Suggestion: "Remove stale entries from x-schemalint-ignore",
+	}
+}
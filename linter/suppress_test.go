@@ -0,0 +1,91 @@
+package linter
+
+import (
+	"testing"
+)
+
+func TestSuppressionForms(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"All": {
+				"x-schemalint-ignore": true,
+				"anyOf": [
+					{"type": "object", "properties": {"name": {"type": "string"}}},
+					{"type": "object", "properties": {"title": {"type": "string"}}}
+				]
+			},
+			"Listed": {
+				"x-schemalint-ignore": ["union-no-discriminator"],
+				"oneOf": [
+					{"type": "object", "properties": {"name": {"type": "string"}}},
+					{"type": "object", "properties": {"title": {"type": "string"}}, "additionalProperties": true}
+				]
+			},
+			"Tree": {
+				"x-schemalint-ignore": {"codes": ["invalid-property-case"], "subtree": true},
+				"type": "object",
+				"properties": {
+					"nested": {"type": "object", "properties": {"bad_name": {"type": "string"}}}
+				}
+			}
+		}
+	}`
+
+	l := NewWithDefaults()
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected only the unsuppressed variant warning, got: %v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Code != CodeAdditionalProps || issue.Path != "$/$defs/Listed/oneOf/1" {
+		t.Errorf("Expected additional-properties on nested variant, got: %v", issue)
+	}
+}
+
+func TestReportUnusedSuppressions(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Stale": {
+				"x-schemalint-ignore": ["union-no-discriminator", "large-union"],
+				"anyOf": [
+					{"type": "object", "properties": {"name": {"type": "string"}}},
+					{"type": "object", "properties": {"title": {"type": "string"}}}
+				]
+			}
+		}
+	}`
+
+	config := DefaultConfig()
+	config.ReportUnusedSuppressions = true
+	l := New(config)
+
+	result, err := l.Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected one unused-suppression issue, got: %v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if issue.Code != CodeUnusedSuppression || issue.Path != "$/$defs/Stale/x-schemalint-ignore" {
+		t.Errorf("Unexpected issue: %v", issue)
+	}
+	if issue.Message != "x-schemalint-ignore for large-union suppresses no issues" {
+		t.Errorf("Unexpected message: %s", issue.Message)
+	}
+	if issue.Line != 4 {
+		t.Errorf("Expected issue on line 4, got %d", issue.Line)
+	}
+}
+
+func TestInvalidSuppression(t *testing.T) {
+	l := NewWithDefaults()
+	if _, err := l.Lint([]byte(`{"x-schemalint-ignore": 42}`)); err == nil {
+		t.Error("Expected error for invalid x-schemalint-ignore value")
+	}
+}
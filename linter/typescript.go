@@ -0,0 +1,73 @@
+package linter
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// checkTSOptionalDiscriminator reports union variants whose discriminator
+// property is not required. Generators declare it optional (kind?: "circle"),
+// so a value without it is assignable to every variant and narrowing on the
+// discriminator cannot tell the variants apart.
+func checkTSOptionalDiscriminator(ctx *RuleContext, node *Node) {
+	reportOptionalDiscriminators(ctx, node, "the generated type declares it optional and TypeScript cannot narrow on it")
+}
+
+// checkTSIndexSignature reports objects with declared properties that also
+// allow additional properties. TypeScript models them with an index
+// signature, which must accept the type of every declared property: true
+// becomes [key: string]: unknown, and a schema is widened to the union of
+// the property types. Either way, misspelled property names type-check.
+// Maps without declared properties become Record<string, T> and are not
+// reported.
+func checkTSIndexSignature(ctx *RuleContext, node *Node) {
+	schema := node.Schema
+	if len(schema.Properties) == 0 {
+		return
+	}
+	issue := Issue{Path: node.Path + "/additionalProperties"}
+	switch {
+	case schema.AdditionalPropertiesSchema != nil:
+		issue.Message = "additionalProperties next to properties becomes an index signature that must also accept every property's type, so TypeScript widens it"
+		issue.Suggestion = "Move the additional entries into a separate map-valued property"
+	case schema.AdditionalProperties != nil && *schema.AdditionalProperties:
+		issue.Message = "additionalProperties: true becomes the index signature [key: string]: unknown, so misspelled properties type-check"
+		issue.Suggestion = "Set additionalProperties: false, or move the additional entries into a separate map-valued property"
+	default:
+		return
+	}
+	ctx.Report(issue)
+}
+
+// checkTSPropertyQuoting reports property names that are not JavaScript
+// identifiers. Generated interfaces must quote them ("content-type": string)
+// and code must access them with brackets. Reserved words are valid
+// property names and are not reported.
+func checkTSPropertyQuoting(ctx *RuleContext, node *Node) {
+	for name := range node.Schema.Properties {
+		if isJSIdentifier(name) {
+			continue
+		}
+		ctx.Report(Issue{
+			Path:       fmt.Sprintf("%s/properties/%s", node.Path, EscapePointerToken(name)),
+			Message:    fmt.Sprintf("Property '%s' is not a JavaScript identifier, so TypeScript types must quote it and code must access it as obj[%q]", name, name),
+			Suggestion: "Rename the property using only letters, digits, _ and $, not starting with a digit",
+		})
+	}
+}
+
+// isJSIdentifier reports whether name is a JavaScript identifier name.
+func isJSIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '$' || r == '_' || unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Pc, r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
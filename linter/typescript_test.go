@@ -0,0 +1,88 @@
+package linter
+
+import (
+	"testing"
+)
+
+func TestTypeScriptLiteralDiscriminators(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"Version": {
+				"oneOf": [
+					{"type": "object", "required": ["version"], "properties": {"version": {"const": 1}}},
+					{"type": "object", "required": ["version"], "properties": {"version": {"const": 2}}}
+				]
+			},
+			"Result": {
+				"oneOf": [
+					{"type": "object", "required": ["ok"], "properties": {"ok": {"const": true}, "value": {"type": "string"}}},
+					{"type": "object", "properties": {"ok": {"const": false}, "error": {"type": "string"}}}
+				]
+			},
+			"Duplicate": {
+				"oneOf": [
+					{"type": "object", "required": ["kind"], "properties": {"kind": {"const": 1}}},
+					{"type": "object", "required": ["kind"], "properties": {"kind": {"const": "1"}}},
+					{"type": "object", "required": ["kind"], "properties": {"kind": {"const": 1}}}
+				]
+			}
+		}
+	}`
+	config := DefaultConfig()
+	config.DiscriminatorFields = []string{"kind", "version", "ok"}
+	config.Profile = ProfileTypeScript
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/$defs/Result/oneOf/1/properties/ok": CodeTSOptionalDiscriminator,
+		"$/$defs/Duplicate/oneOf":              CodeUnionNoDiscriminator,
+	})
+
+	// Other profiles only accept string discriminators
+	result, err = NewWithDefaults().Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	unions := 0
+	for _, issue := range result.Issues {
+		if issue.Code == CodeUnionNoDiscriminator {
+			unions++
+		}
+	}
+	if unions != 3 {
+		t.Errorf("Expected 3 unions without discriminators in the default profile, got %d", unions)
+	}
+}
+
+func TestTypeScriptProfile(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"contentType": {"type": "string"},
+			"$ref_id": {"type": "string"},
+			"class": {"type": "string"},
+			"content-type": {"type": "string"},
+			"2fa": {"type": "boolean"},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"extra": {"type": "object", "properties": {"id": {"type": "integer"}}, "additionalProperties": {"type": "string"}},
+			"loose": {"type": "object", "properties": {"id": {"type": "integer"}}, "additionalProperties": true}
+		}
+	}`
+	config := DefaultConfig()
+	config.PropertyCase = CaseNone
+	config.Profile = ProfileTypeScript
+	result, err := New(config).Lint([]byte(schema))
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+
+	assertIssues(t, result, map[string]IssueCode{
+		"$/properties/content-type":               CodeTSPropertyQuoting,
+		"$/properties/2fa":                        CodeTSPropertyQuoting,
+		"$/properties/extra/additionalProperties": CodeTSIndexSignature,
+		"$/properties/loose/additionalProperties": CodeTSIndexSignature,
+	})
+}
@@ -0,0 +1,172 @@
+package linter
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// SkipSubschemas is returned by a Visitor to skip the subschemas of the
+// schema being visited. Walk does not return it.
+var SkipSubschemas = errors.New("skip subschemas")
+
+// Visit describes a schema reached by Walk.
+type Visit struct {
+	// Schema is the schema being visited.
+	Schema *Schema
+	// Pointer is the RFC 6901 JSON Pointer of the schema within its document
+	// ("" for the root).
+	Pointer string
+	// URI is the document containing the schema when it was reached through a
+	// followed $ref into another document; it is empty within the walked document.
+	URI string
+	// Parent is the schema containing this one, or nil for the root.
+	Parent *Schema
+	// Keyword is the keyword of Parent under which the schema appears, such as
+	// "properties" or "anyOf", or "$ref" for a followed reference target.
+	Keyword string
+	// Depth is the nesting level: 0 for the root, 1 for its subschemas, and so on.
+	Depth int
+}
+
+// Visitor is called by Walk for every schema visited. Returning SkipSubschemas
+// skips the schema's subschemas; any other error stops the walk.
+type Visitor func(v Visit) error
+
+// WalkOption configures Walk.
+type WalkOption func(*walker)
+
+// FollowRefs makes Walk visit the targets of $refs, resolved with resolver,
+// after the subschemas of the referring schema. Each target is visited at most
+// once per walk, so recursive references terminate; unresolvable references
+// are skipped. By default $refs are not followed.
+func FollowRefs(resolver *Resolver) WalkOption {
+	return func(w *walker) {
+		w.resolver = resolver
+	}
+}
+
+// walkAt reports pointers relative to pointer, for a schema embedded in a
+// larger document such as an OpenAPI document.
+func walkAt(pointer string) WalkOption {
+	return func(w *walker) {
+		w.pointer = pointer
+	}
+}
+
+// Walk visits schema and every subschema nested in it, depth first, in a
+// stable order. Subschemas are visited for every keyword that holds schemas
+// (definitions, properties, items, composition keywords and so on).
+func Walk(schema *Schema, visitor Visitor, opts ...WalkOption) error {
+	w := &walker{visitor: visitor, followed: make(map[*Schema]bool)}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w.walk(Visit{Schema: schema, Pointer: w.pointer})
+}
+
+type walker struct {
+	visitor  Visitor
+	resolver *Resolver
+	pointer  string
+	followed map[*Schema]bool
+}
+
+func (w *walker) walk(v Visit) error {
+	if v.Schema == nil {
+		return nil
+	}
+	if err := w.visitor(v); err != nil {
+		if errors.Is(err, SkipSubschemas) {
+			return nil
+		}
+		return err
+	}
+
+	for _, sub := range v.Schema.subschemas() {
+		err := w.walk(Visit{
+			Schema:  sub.schema,
+			Pointer: v.Pointer + sub.pointer,
+			URI:     v.URI,
+			Parent:  v.Schema,
+			Keyword: sub.keyword,
+			Depth:   v.Depth + 1,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.resolver == nil || v.Schema.Ref == "" {
+		return nil
+	}
+	target, err := w.resolver.Resolve(v.Schema, v.Schema.Ref)
+	if err != nil || w.followed[target.Schema] {
+		return nil
+	}
+	w.followed[target.Schema] = true
+	return w.walk(Visit{
+		Schema:  target.Schema,
+		Pointer: target.Pointer,
+		URI:     target.URI,
+		Parent:  v.Schema,
+		Keyword: "$ref",
+		Depth:   v.Depth + 1,
+	})
+}
+
+// subschema is a schema nested directly in another.
+type subschema struct {
+	keyword string
+	pointer string // relative to the parent, e.g. "/properties/name"
+	schema  *Schema
+}
+
+// subschemas returns the schemas nested directly in s, in a stable order.
+func (s *Schema) subschemas() []subschema {
+	var subs []subschema
+	named := func(keyword string, schemas map[string]*Schema) {
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			subs = append(subs, subschema{keyword, "/" + keyword + "/" + EscapePointerToken(name), schemas[name]})
+		}
+	}
+	indexed := func(keyword string, schemas []*Schema) {
+		for i, schema := range schemas {
+			subs = append(subs, subschema{keyword, "/" + keyword + "/" + strconv.Itoa(i), schema})
+		}
+	}
+	single := func(keyword string, schema *Schema) {
+		if schema != nil {
+			subs = append(subs, subschema{keyword, "/" + keyword, schema})
+		}
+	}
+
+	named("$defs", s.Defs)
+	named("definitions", s.Definitions)
+	named("properties", s.Properties)
+	named("patternProperties", s.PatternProperties)
+	single("additionalProperties", s.AdditionalPropertiesSchema)
+	single("propertyNames", s.PropertyNames)
+	single("unevaluatedProperties", s.UnevaluatedProperties)
+	named("dependentSchemas", s.DependentSchemas)
+	named("dependencies", s.Dependencies)
+	indexed("prefixItems", s.PrefixItems)
+	indexed("items", s.ItemsList)
+	single("items", s.Items)
+	single("additionalItems", s.AdditionalItems)
+	single("contains", s.Contains)
+	single("unevaluatedItems", s.UnevaluatedItems)
+	indexed("allOf", s.AllOf)
+	indexed("anyOf", s.AnyOf)
+	indexed("oneOf", s.OneOf)
+	single("not", s.Not)
+	single("if", s.If)
+	single("then", s.Then)
+	single("else", s.Else)
+	return subs
+}
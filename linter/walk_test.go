@@ -0,0 +1,180 @@
+package linter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"$defs": {
+			"a/b": {"type": "string"}
+		},
+		"properties": {
+			"tags": {"items": {"type": "string"}},
+			"meta": {"additionalProperties": {"type": "integer"}}
+		},
+		"allOf": [{"anyOf": [{"type": "string"}, {"oneOf": [{"const": 1}]}]}]
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var pointers []string
+	visits := make(map[string]Visit)
+	err = Walk(schema, func(v Visit) error {
+		pointers = append(pointers, v.Pointer)
+		visits[v.Pointer] = v
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+
+	want := []string{
+		"",
+		"/$defs/a~1b",
+		"/properties/meta",
+		"/properties/meta/additionalProperties",
+		"/properties/tags",
+		"/properties/tags/items",
+		"/allOf/0",
+		"/allOf/0/anyOf/0",
+		"/allOf/0/anyOf/1",
+		"/allOf/0/anyOf/1/oneOf/0",
+	}
+	if !reflect.DeepEqual(pointers, want) {
+		t.Errorf("Expected pointers %v, got %v", want, pointers)
+	}
+
+	v := visits["/allOf/0/anyOf/1/oneOf/0"]
+	if v.Keyword != "oneOf" || v.Depth != 3 || v.Parent != visits["/allOf/0/anyOf/1"].Schema {
+		t.Errorf("Unexpected visit: %+v", v)
+	}
+	if root := visits[""]; root.Parent != nil || root.Keyword != "" || root.Depth != 0 {
+		t.Errorf("Unexpected root visit: %+v", root)
+	}
+}
+
+func TestWalkSkipAndStop(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"properties": {
+			"a": {"properties": {"nested": {"type": "string"}}},
+			"b": {"type": "string"}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var pointers []string
+	_ = Walk(schema, func(v Visit) error {
+		pointers = append(pointers, v.Pointer)
+		if v.Pointer == "/properties/a" {
+			return SkipSubschemas
+		}
+		return nil
+	})
+	if want := []string{"", "/properties/a", "/properties/b"}; !reflect.DeepEqual(pointers, want) {
+		t.Errorf("Expected %v, got %v", want, pointers)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = Walk(schema, func(v Visit) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Errorf("Expected walk to stop at the first visit, got %v after %d visits", err, count)
+	}
+}
+
+func TestWalkFollowRefs(t *testing.T) {
+	resolver := NewResolver(MapLoader{
+		"https://example.com/other.json": []byte(`{"$defs": {"Ext": {"type": "string"}}}`),
+	})
+	schema, err := resolver.AddDocument("https://example.com/main.json", "main.json", []byte(`{
+		"properties": {
+			"node": {"$ref": "#/$defs/Node"},
+			"ext": {"$ref": "other.json#/$defs/Ext"}
+		},
+		"$defs": {
+			"Node": {"properties": {"next": {"$ref": "#/$defs/Node"}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to add document: %v", err)
+	}
+
+	collect := func(opts ...WalkOption) map[string]Visit {
+		visits := make(map[string]Visit)
+		_ = Walk(schema, func(v Visit) error {
+			if v.Keyword == "$ref" {
+				visits[v.URI+"#"+v.Pointer] = v
+			}
+			return nil
+		}, opts...)
+		return visits
+	}
+
+	if refs := collect(); len(refs) != 0 {
+		t.Errorf("Expected $refs not to be followed by default, got %v", refs)
+	}
+
+	refs := collect(FollowRefs(resolver))
+	if len(refs) != 2 {
+		t.Fatalf("Expected each target to be visited once, got %v", refs)
+	}
+	ext, ok := refs["https://example.com/other.json#/$defs/Ext"]
+	if !ok || ext.Parent != schema.Properties["ext"] {
+		t.Errorf("Expected external target visited from its $ref, got %+v", ext)
+	}
+}
+
+func TestWalkAllKeywords(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"patternProperties": {"^x-": true},
+		"propertyNames": {"pattern": "^[a-z]+$"},
+		"unevaluatedProperties": false,
+		"dependentSchemas": {"a": {"required": ["b"]}},
+		"dependencies": {"c": {"required": ["d"]}, "e": ["f"]},
+		"prefixItems": [{"type": "string"}],
+		"contains": {"type": "integer"},
+		"unevaluatedItems": false,
+		"not": {"type": "null"},
+		"if": {"required": ["kind"]},
+		"then": {"properties": {"kind": {"const": "a"}}},
+		"else": {}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	keywords := make(map[string]string)
+	_ = Walk(schema, func(v Visit) error {
+		keywords[v.Pointer] = v.Keyword
+		return nil
+	})
+
+	want := map[string]string{
+		"":                       "",
+		"/patternProperties/^x-": "patternProperties",
+		"/propertyNames":         "propertyNames",
+		"/unevaluatedProperties": "unevaluatedProperties",
+		"/dependentSchemas/a":    "dependentSchemas",
+		"/dependencies/c":        "dependencies",
+		"/prefixItems/0":         "prefixItems",
+		"/contains":              "contains",
+		"/unevaluatedItems":      "unevaluatedItems",
+		"/not":                   "not",
+		"/if":                    "if",
+		"/then":                  "then",
+		"/then/properties/kind":  "properties",
+		"/else":                  "else",
+	}
+	if !reflect.DeepEqual(keywords, want) {
+		t.Errorf("Expected %v, got %v", want, keywords)
+	}
+}
@@ -0,0 +1,160 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlToJSON converts a YAML document to JSON, keeping mapping keys in source
+// order, and returns the YAML source position of every value.
+func yamlToJSON(data []byte) ([]byte, *sourceMap, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, fmt.Errorf("empty YAML document")
+	}
+	c := &yamlConverter{spans: make(map[string]span)}
+	root := doc.Content[0]
+	end, err := c.node(root, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	c.spans[""] = span{Start: yamlPosition(root), End: end}
+	return c.buf.Bytes(), &sourceMap{spans: c.spans}, nil
+}
+
+// yamlConverter writes YAML nodes as JSON and records their positions.
+type yamlConverter struct {
+	buf   bytes.Buffer
+	spans map[string]span
+}
+
+// yamlPosition returns the position of node. YAML positions carry no byte
+// offset, so documents converted from YAML cannot be fixed in place.
+func yamlPosition(node *yaml.Node) Position {
+	return Position{Line: node.Line, Column: node.Column}
+}
+
+// node writes node, found at pointer, as JSON and returns the position of its
+// last line.
+func (c *yamlConverter) node(node *yaml.Node, pointer string) (Position, error) {
+	end := yamlPosition(node)
+	switch node.Kind {
+	case yaml.AliasNode:
+		if _, err := c.node(node.Alias, pointer); err != nil {
+			return end, err
+		}
+
+	case yaml.MappingNode:
+		c.buf.WriteByte('{')
+		members := yamlMembers(node)
+		for i, member := range members {
+			if i > 0 {
+				c.buf.WriteByte(',')
+			}
+			// Keys such as response codes (200) are scalars of any type
+			key, _ := json.Marshal(member.key.Value)
+			c.buf.Write(key)
+			c.buf.WriteByte(':')
+			child := pointer + "/" + EscapePointerToken(member.key.Value)
+			childEnd, err := c.node(member.value, child)
+			if err != nil {
+				return end, err
+			}
+			c.spans[child] = span{Key: yamlPosition(member.key), HasKey: true, Start: yamlPosition(member.value), End: childEnd}
+			end = later(end, childEnd)
+		}
+		c.buf.WriteByte('}')
+
+	case yaml.SequenceNode:
+		c.buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				c.buf.WriteByte(',')
+			}
+			child := pointer + "/" + strconv.Itoa(i)
+			childEnd, err := c.node(item, child)
+			if err != nil {
+				return end, err
+			}
+			c.spans[child] = span{Start: yamlPosition(item), End: childEnd}
+			end = later(end, childEnd)
+		}
+		c.buf.WriteByte(']')
+
+	case yaml.ScalarNode:
+		var value any
+		switch node.ShortTag() {
+		case "!!str", "!!timestamp", "!!binary":
+			value = node.Value
+		default:
+			if err := node.Decode(&value); err != nil {
+				return end, err
+			}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return end, fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		c.buf.Write(data)
+
+	default:
+		return end, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+	return end, nil
+}
+
+// yamlMember is a key/value pair of a YAML mapping.
+type yamlMember struct {
+	key, value *yaml.Node
+}
+
+// yamlMembers returns the members of a mapping in source order. Members
+// merged in with "<<" come first unless the mapping sets them itself.
+func yamlMembers(node *yaml.Node) []yamlMember {
+	var merged, own []yamlMember
+	keys := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() != "!!merge" {
+			own = append(own, yamlMember{key, value})
+			keys[key.Value] = true
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			if source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind == yaml.MappingNode {
+				merged = append(merged, yamlMembers(source)...)
+			}
+		}
+	}
+
+	members := make([]yamlMember, 0, len(merged)+len(own))
+	for _, member := range merged {
+		if !keys[member.key.Value] {
+			keys[member.key.Value] = true
+			members = append(members, member)
+		}
+	}
+	return append(members, own...)
+}
+
+// later returns the later of two positions.
+func later(a, b Position) Position {
+	if b.Line > a.Line || (b.Line == a.Line && b.Column > a.Column) {
+		return b
+	}
+	return a
+}